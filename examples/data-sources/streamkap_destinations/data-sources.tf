@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    streamkap = {
+      source  = "streamkap-com/streamkap"
+      version = ">= 2.0.0"
+    }
+  }
+  required_version = ">= 1.0.0"
+}
+
+provider "streamkap" {}
+
+data "streamkap_destinations" "example-destinations" {
+  connector  = "snowflake"
+  name_regex = "^orders-"
+  tag_id     = "670e5bab0d119c0d1f8cda9d"
+}
+
+output "example-destinations" {
+  value = data.streamkap_destinations.example-destinations.destinations[*].id
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    streamkap = {
+      source  = "streamkap-com/streamkap"
+      version = ">= 2.0.0"
+    }
+  }
+  required_version = ">= 1.0.0"
+}
+
+provider "streamkap" {}
+
+data "streamkap_source" "example-source" {
+  name = "orders-postgresql"
+}
+
+output "example-source" {
+  value = data.streamkap_source.example-source.topics
+}
@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    streamkap = {
+      source  = "streamkap-com/streamkap"
+      version = ">= 2.0.0"
+    }
+  }
+  required_version = ">= 1.0.0"
+}
+
+provider "streamkap" {}
+
+data "streamkap_source_topics" "example-source-topics" {
+  source_id  = "67adbcc172417ef6338e01a1"
+  name_regex = "^public\\."
+}
+
+output "example-source-topics" {
+  value = data.streamkap_source_topics.example-source-topics.topics
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    streamkap = {
+      source  = "streamkap-com/streamkap"
+      version = ">= 2.0.0"
+    }
+    restapi = {
+      source = "Mastercard/restapi"
+    }
+  }
+  required_version = ">= 1.10.0"
+}
+
+provider "streamkap" {}
+
+ephemeral "streamkap_access_token" "example-access-token" {}
+
+# Another provider calling the Streamkap API with the token, which is never
+# saved in the plan or state.
+provider "restapi" {
+  uri = "https://api.streamkap.com"
+  headers = {
+    Authorization = "Bearer ${ephemeral.streamkap_access_token.example-access-token.access_token}"
+  }
+}
@@ -1,2 +1,5 @@
 # Destination Snowflake can be imported by specifying the identifier.
-terraform import streamkap_destination_clickhouse.example-destination-clickhouse 665e894ebb3753f38d983cee
\ No newline at end of file
+terraform import streamkap_destination_clickhouse.example-destination-clickhouse 665e894ebb3753f38d983cee
+
+# It can also be imported by name, which must match exactly one destination.
+terraform import streamkap_destination_clickhouse.example-destination-clickhouse name:example-destination-clickhouse
\ No newline at end of file
@@ -1,2 +1,5 @@
 # Destination Kafka can be imported by specifying the identifier.
-terraform import streamkap_destination_kafka.example-destination-kafka 665e894ebb3753f38d983cee
\ No newline at end of file
+terraform import streamkap_destination_kafka.example-destination-kafka 665e894ebb3753f38d983cee
+
+# It can also be imported by name, which must match exactly one destination.
+terraform import streamkap_destination_kafka.example-destination-kafka name:example-destination-kafka
\ No newline at end of file
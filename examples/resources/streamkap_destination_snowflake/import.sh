@@ -1,2 +1,5 @@
 # Destination Snowflake can be imported by specifying the identifier.
-terraform import streamkap_destination_snowflake.example-destination-snowflake 665e894ebb3753f38d983cee
\ No newline at end of file
+terraform import streamkap_destination_snowflake.example-destination-snowflake 665e894ebb3753f38d983cee
+
+# It can also be imported by name, which must match exactly one destination.
+terraform import streamkap_destination_snowflake.example-destination-snowflake name:example-destination-snowflake
\ No newline at end of file
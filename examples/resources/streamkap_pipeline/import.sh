@@ -1,2 +1,5 @@
 # Destination Snowflake can be imported by specifying the identifier.
-terraform import streamkap_pipeline.example-pipeline 665e894ebb3753f38d983cee
\ No newline at end of file
+terraform import streamkap_pipeline.example-pipeline 665e894ebb3753f38d983cee
+
+# It can also be imported by name, which must match exactly one pipeline.
+terraform import streamkap_pipeline.example-pipeline name:example-pipeline
\ No newline at end of file
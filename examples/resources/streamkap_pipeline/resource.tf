@@ -123,15 +123,21 @@ resource "streamkap_pipeline" "example" {
       ]
     },
     {
-      id = data.streamkap_transform.another-example-transform.id
-      topics = [
-        "test",
-      ]
+      name          = data.streamkap_transform.another-example-transform.name
+      topic_pattern = "test*"
     }
   ]
   tags = [
     data.streamkap_tag.production-tag.id,
   ]
+  state = "running"
+  restart_on_change = {
+    schema_version = "2024-06-01"
+  }
+  wait_for_running = true
+  timeouts {
+    create = "30m"
+  }
 }
 
 output "example-pipeline" {
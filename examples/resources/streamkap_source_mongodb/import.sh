@@ -1,2 +1,5 @@
 # Source MongoDB can be imported by specifying the identifier.
-terraform import streamkap_source_mongodb.example-source-mongodb 665e894ebb3753f38d983cee
\ No newline at end of file
+terraform import streamkap_source_mongodb.example-source-mongodb 665e894ebb3753f38d983cee
+
+# It can also be imported by name, which must match exactly one source.
+terraform import streamkap_source_mongodb.example-source-mongodb name:example-source-mongodb
\ No newline at end of file
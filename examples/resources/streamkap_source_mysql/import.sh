@@ -1,2 +1,5 @@
 # Source MySQL can be imported by specifying the identifier.
-terraform import streamkap_source_mysql.example-source-mysql 665e894ebb3753f38d983cee
\ No newline at end of file
+terraform import streamkap_source_mysql.example-source-mysql 665e894ebb3753f38d983cee
+
+# It can also be imported by name, which must match exactly one source.
+terraform import streamkap_source_mysql.example-source-mysql name:test-source-mysql
\ No newline at end of file
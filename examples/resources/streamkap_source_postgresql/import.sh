@@ -1,2 +1,5 @@
 # Destination Snowflake can be imported by specifying the identifier.
-terraform import streamkap_source_postgresql.example-source-postgresql 665e894ebb3753f38d983cee
\ No newline at end of file
+terraform import streamkap_source_postgresql.example-source-postgresql 665e894ebb3753f38d983cee
+
+# It can also be imported by name, which must match exactly one source.
+terraform import streamkap_source_postgresql.example-source-postgresql name:example-source-postgresql
\ No newline at end of file
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    streamkap = {
+      source  = "streamkap-com/streamkap"
+      version = ">= 2.0.0"
+    }
+  }
+  required_version = ">= 1.0.0"
+}
+
+provider "streamkap" {}
+
+variable "source_id" {
+  type        = string
+  description = "The identifier of the source to backfill"
+}
+
+resource "streamkap_source_snapshot" "example-backfill" {
+  source_id = var.source_id
+  tables = [
+    "public.orders",
+  ]
+  # Change the value to fire a new snapshot
+  triggers = {
+    backfill = "2024-06-01"
+  }
+  wait_for_completion = true
+}
+
+output "example-backfill" {
+  value = streamkap_source_snapshot.example-backfill.status
+}
@@ -1,2 +1,5 @@
 # Source SQL Server can be imported by specifying the identifier
-terraform import streamkap_source_sqlserver.example-source-sqlserver 686dfd294321ea8a67d6d190
\ No newline at end of file
+terraform import streamkap_source_sqlserver.example-source-sqlserver 686dfd294321ea8a67d6d190
+
+# It can also be imported by name, which must match exactly one source.
+terraform import streamkap_source_sqlserver.example-source-sqlserver name:example-source-sqlserver
\ No newline at end of file
@@ -0,0 +1,2 @@
+# StreamKap tag can be imported by specifying the identifier
+terraform import streamkap_tag.example-tag 670e5ca40afe1d3983ce0c22
\ No newline at end of file
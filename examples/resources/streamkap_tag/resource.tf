@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    streamkap = {
+      source  = "streamkap-com/streamkap"
+      version = ">= 2.0.0"
+    }
+  }
+  required_version = ">= 1.0.0"
+}
+
+provider "streamkap" {}
+
+resource "streamkap_tag" "example-tag" {
+  name        = "team-data-platform"
+  description = "Pipelines owned by the data platform team"
+  type        = ["pipelines"]
+}
+
+output "example-tag" {
+  value = streamkap_tag.example-tag.id
+}
@@ -20,6 +20,14 @@ resource "streamkap_topic" "example-topic2" {
   partition_count = 25
 }
 
+resource "streamkap_topic" "example-topic3" {
+  topic_id           = "orders-compacted"
+  partition_count    = 6
+  replication_factor = 3
+  retention_ms       = -1
+  cleanup_policy     = "compact"
+}
+
 output "example-topic" {
   value = streamkap_topic.example-topic.topic_id
 }
\ No newline at end of file
@@ -0,0 +1,2 @@
+# StreamKap transform can be imported by specifying the identifier
+terraform import streamkap_transform.example-transform 67d43b4ed21e8f093edae34b
\ No newline at end of file
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    streamkap = {
+      source  = "streamkap-com/streamkap"
+      version = ">= 2.0.0"
+    }
+  }
+  required_version = ">= 1.0.0"
+}
+
+provider "streamkap" {}
+
+resource "streamkap_transform" "example-transform" {
+  name                = "mask-emails"
+  transform_type      = "map_filter"
+  language            = "JavaScript"
+  code_file           = "${path.module}/mask_emails.js"
+  input_topic_pattern = "source_67adbcc172417ef6338e01a1\\.public\\.users"
+}
+
+output "example-transform" {
+  value = streamkap_transform.example-transform.id
+}
@@ -4,9 +4,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// tokenRefreshWindow is how long before its expiry an access token is
+// proactively refreshed.
+const tokenRefreshWindow = time.Minute
+
+const DefaultTokenTimeout = 30 * time.Second
+
 type Token struct {
 	AccessToken  string `json:"accessToken"`
 	Expires      string `json:"expires"`
@@ -19,7 +31,61 @@ type GetAccessTokenRequest struct {
 	Secret   string `json:"secret"`
 }
 
-func (s *streamkapAPI) GetAccessToken(clientID, secret string) (*Token, error) {
+type RefreshAccessTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Credentials are the API client credentials as stored in a credentials
+// file, e.g. {"client_id": "...", "secret": "..."}.
+type Credentials struct {
+	ClientID string `json:"client_id"`
+	Secret   string `json:"secret"`
+}
+
+// ReadCredentialsFile reads the client credentials from the JSON file at
+// path, so they do not have to be passed through environment variables.
+func ReadCredentialsFile(path string) (*Credentials, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials file: %w", err)
+	}
+	var creds Credentials
+	if err := json.Unmarshal(content, &creds); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: expected a JSON object with client_id and secret: %w", path, err)
+	}
+	return &creds, nil
+}
+
+type authRequestKey struct{}
+
+// withAuthRequest marks the requests built from ctx as token requests, which
+// are sent without an Authorization header and never trigger a refresh.
+func withAuthRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authRequestKey{}, true)
+}
+
+func isAuthRequest(req *http.Request) bool {
+	auth, _ := req.Context().Value(authRequestKey{}).(bool)
+	return auth
+}
+
+func (s *streamkapAPI) GetAccessToken(ctx context.Context, clientID, secret string) (*Token, error) {
+	token, err := s.requestAccessToken(ctx, clientID, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the credentials so the token can be exchanged again once it
+	// expires and cannot be refreshed.
+	s.tokenMu.Lock()
+	s.clientID = clientID
+	s.secret = secret
+	s.tokenMu.Unlock()
+
+	return token, nil
+}
+
+func (s *streamkapAPI) requestAccessToken(ctx context.Context, clientID, secret string) (*Token, error) {
 	body := &GetAccessTokenRequest{
 		ClientID: clientID,
 		Secret:   secret,
@@ -28,7 +94,10 @@ func (s *streamkapAPI) GetAccessToken(clientID, secret string) (*Token, error) {
 	if err != nil {
 		return nil, err
 	}
-	ctx := context.Background()
+	// Exchanging credentials has no side effect, so it is safe to retry.
+	ctx = withAuthRequest(withRetrySafe(ctx))
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.TokenTimeout)
+	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/auth/access-token", bytes.NewBuffer(payload))
 	if err != nil {
@@ -43,3 +112,155 @@ func (s *streamkapAPI) GetAccessToken(clientID, secret string) (*Token, error) {
 
 	return &result, nil
 }
+
+func (s *streamkapAPI) SetToken(token *Token) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+
+	s.setTokenLocked(token)
+}
+
+func (s *streamkapAPI) setTokenLocked(token *Token) {
+	s.token = token
+	s.tokenExpiry = tokenExpiry(token, time.Now())
+}
+
+// accessToken returns the token to authenticate a request with, refreshing
+// it first when it is about to expire.
+func (s *streamkapAPI) accessToken(ctx context.Context) (*Token, error) {
+	s.tokenMu.Lock()
+	token := s.token
+	expiry := s.tokenExpiry
+	s.tokenMu.Unlock()
+
+	if token == nil || expiry.IsZero() || time.Until(expiry) > tokenRefreshWindow {
+		return token, nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Access token expires at %s, refreshing", expiry.Format(time.RFC3339)))
+	return s.refreshAccessToken(ctx, token)
+}
+
+// tokenRefresh is a refresh of the stale token in progress. done is closed
+// once token or err is set.
+type tokenRefresh struct {
+	stale *Token
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// refreshAccessToken replaces stale with a new token. Concurrent callers that
+// observed the same stale token wait for a single refresh and share its
+// result. The exchange runs without tokenMu held, so requests that still
+// have a valid token are not blocked by a slow token endpoint.
+func (s *streamkapAPI) refreshAccessToken(ctx context.Context, stale *Token) (*Token, error) {
+	s.tokenMu.Lock()
+	if s.token != stale {
+		// Another request already refreshed the token.
+		token := s.token
+		s.tokenMu.Unlock()
+		return token, nil
+	}
+	if r := s.refresh; r != nil && r.stale == stale {
+		s.tokenMu.Unlock()
+		select {
+		case <-r.done:
+			return r.token, r.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	r := &tokenRefresh{stale: stale, done: make(chan struct{})}
+	s.refresh = r
+	clientID, secret := s.clientID, s.secret
+	s.tokenMu.Unlock()
+
+	r.token, r.err = s.exchangeToken(ctx, stale, clientID, secret)
+
+	s.tokenMu.Lock()
+	if r.err == nil {
+		s.setTokenLocked(r.token)
+	}
+	s.refresh = nil
+	s.tokenMu.Unlock()
+	close(r.done)
+
+	return r.token, r.err
+}
+
+// exchangeToken exchanges the refresh token of stale for a new token, or the
+// credentials when that fails.
+func (s *streamkapAPI) exchangeToken(ctx context.Context, stale *Token, clientID, secret string) (*Token, error) {
+	var token *Token
+	var err error
+	if stale != nil && stale.RefreshToken != "" {
+		token, err = s.exchangeRefreshToken(ctx, stale.RefreshToken)
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to refresh access token: %s", err))
+		}
+	}
+	if token == nil {
+		if clientID == "" || secret == "" {
+			if err == nil {
+				err = errors.New("no refresh token or credentials available")
+			}
+			return nil, fmt.Errorf("access token expired and could not be refreshed: %w", err)
+		}
+		token, err = s.requestAccessToken(ctx, clientID, secret)
+		if err != nil {
+			return nil, fmt.Errorf("access token expired and could not be refreshed: %w", err)
+		}
+	}
+	return token, nil
+}
+
+func (s *streamkapAPI) exchangeRefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
+	payload, err := json.Marshal(&RefreshAccessTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, err
+	}
+	ctx = withAuthRequest(withRetrySafe(ctx))
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.TokenTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/auth/refresh", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	var result Token
+	err = s.doRequest(ctx, req, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.RefreshToken == "" {
+		result.RefreshToken = refreshToken
+	}
+
+	return &result, nil
+}
+
+// ExpiresAt returns when a token issued at now expires, or the zero time when
+// the API did not say.
+func (t *Token) ExpiresAt(now time.Time) time.Time {
+	return tokenExpiry(t, now)
+}
+
+// tokenExpiry returns when token expires, preferring the relative ExpiresIn
+// over the absolute Expires timestamp. A zero time means the expiry is
+// unknown and the token is only refreshed when the API rejects it.
+func tokenExpiry(token *Token, now time.Time) time.Time {
+	if token == nil {
+		return time.Time{}
+	}
+	if token.ExpiresIn > 0 {
+		return now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if token.Expires != "" {
+		if expires, err := time.Parse(time.RFC3339, token.Expires); err == nil {
+			return expires
+		}
+	}
+	return time.Time{}
+}
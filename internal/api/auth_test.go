@@ -0,0 +1,302 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenServer issues numbered access tokens and only accepts the latest one.
+type tokenServer struct {
+	issued    atomic.Int32
+	refreshed atomic.Int32
+	expiresIn int64
+}
+
+func (ts *tokenServer) current() string {
+	return fmt.Sprintf("token-%d", ts.issued.Load())
+}
+
+func (ts *tokenServer) issue(w http.ResponseWriter) {
+	n := ts.issued.Add(1)
+	json.NewEncoder(w).Encode(Token{
+		AccessToken:  fmt.Sprintf("token-%d", n),
+		ExpiresIn:    ts.expiresIn,
+		RefreshToken: fmt.Sprintf("refresh-%d", n),
+	})
+}
+
+func (ts *tokenServer) handler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/auth/access-token":
+		ts.issue(w)
+	case "/auth/refresh":
+		ts.refreshed.Add(1)
+		ts.issue(w)
+	default:
+		if r.Header.Get("Authorization") != "Bearer "+ts.current() {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(APIErrorResponse{Detail: "invalid token"})
+			return
+		}
+		json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{{ID: "src"}}})
+	}
+}
+
+func TestRefreshOnUnauthorized(t *testing.T) {
+	ts := &tokenServer{expiresIn: 3600}
+	client := newTestClient(t, ts.handler)
+
+	token, err := client.GetAccessToken(context.Background(), "id", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client.SetToken(token)
+
+	// Revoke the token server side.
+	ts.issued.Add(1)
+
+	if _, err := client.GetSource(context.Background(), "src"); err != nil {
+		t.Fatalf("expected request to be replayed with a new token, got %s", err)
+	}
+	if got := ts.refreshed.Load(); got != 1 {
+		t.Errorf("expected 1 refresh, got %d", got)
+	}
+}
+
+func TestRefreshBeforeExpiry(t *testing.T) {
+	ts := &tokenServer{expiresIn: 30}
+	client := newTestClient(t, ts.handler)
+
+	token, err := client.GetAccessToken(context.Background(), "id", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client.SetToken(token)
+
+	if _, err := client.GetSource(context.Background(), "src"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := ts.refreshed.Load(); got != 1 {
+		t.Errorf("expected the token to be refreshed before use, got %d refreshes", got)
+	}
+}
+
+func TestRefreshFallsBackToCredentials(t *testing.T) {
+	ts := &tokenServer{expiresIn: 3600}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/refresh" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(APIErrorResponse{Detail: "refresh token expired"})
+			return
+		}
+		ts.handler(w, r)
+	})
+
+	token, err := client.GetAccessToken(context.Background(), "id", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client.SetToken(token)
+	ts.issued.Add(1)
+
+	if _, err := client.GetSource(context.Background(), "src"); err != nil {
+		t.Fatalf("expected credentials to be exchanged again, got %s", err)
+	}
+}
+
+func TestRefreshIsSharedByConcurrentRequests(t *testing.T) {
+	ts := &tokenServer{expiresIn: 3600}
+	client := newTestClient(t, ts.handler)
+
+	token, err := client.GetAccessToken(context.Background(), "id", "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client.SetToken(token)
+	ts.issued.Add(1)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetSource(context.Background(), "src")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+	if got := ts.refreshed.Load(); got != 1 {
+		t.Errorf("expected a single refresh, got %d", got)
+	}
+}
+
+func TestSlowRefreshDoesNotBlockRequests(t *testing.T) {
+	ts := &tokenServer{expiresIn: 3600}
+	refreshing := make(chan struct{})
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	unblock := func() { releaseOnce.Do(func() { close(release) }) }
+	defer unblock()
+	var rejected atomic.Bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/refresh":
+			close(refreshing)
+			<-release
+			ts.handler(w, r)
+		case "/auth/access-token":
+			ts.handler(w, r)
+		default:
+			// Reject the first request only, the others still get through
+			// with the token being refreshed.
+			if rejected.CompareAndSwap(false, true) {
+				w.WriteHeader(http.StatusUnauthorized)
+				json.NewEncoder(w).Encode(APIErrorResponse{Detail: "invalid token"})
+				return
+			}
+			json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{{ID: "src"}}})
+		}
+	})
+	client.SetToken(&Token{AccessToken: "token-0", ExpiresIn: 3600, RefreshToken: "refresh-0"})
+
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := client.GetSource(context.Background(), "src")
+		refreshed <- err
+	}()
+	select {
+	case <-refreshing:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the token to be refreshed")
+	}
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := client.GetSource(context.Background(), "src")
+		done <- err
+	}()
+	go func() {
+		_, err := client.GetAccessToken(context.Background(), "id", "secret")
+		done <- err
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("request blocked by the token refresh")
+		}
+	}
+
+	unblock()
+	if err := <-refreshed; err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if got := tokenExpiry(&Token{ExpiresIn: 60}, now); !got.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected expiry from ExpiresIn, got %s", got)
+	}
+	if got := tokenExpiry(&Token{Expires: "2026-01-01T01:00:00Z"}, now); !got.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected expiry from Expires, got %s", got)
+	}
+	if got := tokenExpiry(&Token{}, now); !got.IsZero() {
+		t.Errorf("expected unknown expiry, got %s", got)
+	}
+}
+
+func TestGetAccessTokenCancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	if _, err := client.GetAccessToken(ctx, "id", "secret"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the token exchange to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected a cancelled token exchange to return immediately, took %s", elapsed)
+	}
+}
+
+func TestGetAccessTokenTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	client.cfg.TokenTimeout = 20 * time.Millisecond
+
+	if _, err := client.GetAccessToken(context.Background(), "id", "secret"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the token exchange to time out, got %v", err)
+	}
+}
+
+func TestPreIssuedTokenIsNotRefreshed(t *testing.T) {
+	ts := &tokenServer{}
+	client := newTestClient(t, ts.handler)
+	client.SetToken(&Token{AccessToken: "pre-issued"})
+
+	_, err := client.GetSource(context.Background(), "src")
+	if err == nil {
+		t.Fatal("expected the rejected token to fail the call")
+	}
+	if ts.issued.Load() != 0 {
+		t.Error("expected no token exchange without credentials")
+	}
+}
+
+func TestReadCredentialsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials.json")
+	if err := os.WriteFile(path, []byte(`{"client_id": "id", "secret": "s3cret"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := ReadCredentialsFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if creds.ClientID != "id" || creds.Secret != "s3cret" {
+		t.Errorf("unexpected credentials %+v", creds)
+	}
+
+	if _, err := ReadCredentialsFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("client_id=id"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadCredentialsFile(invalid); err == nil {
+		t.Error("expected an error for a non-JSON file")
+	}
+}
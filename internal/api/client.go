@@ -3,11 +3,11 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -15,7 +15,7 @@ import (
 const errorBodySnippetLimit = 512
 
 type StreamkapAPI interface {
-	GetAccessToken(clientID, secret string) (*Token, error)
+	GetAccessToken(ctx context.Context, clientID, secret string) (*Token, error)
 	SetToken(token *Token)
 
 	//Source APIs
@@ -23,28 +23,50 @@ type StreamkapAPI interface {
 	UpdateSource(ctx context.Context, sourceID string, reqPayload Source) (*Source, error)
 	GetSource(ctx context.Context, sourceID string) (*Source, error)
 	DeleteSource(ctx context.Context, sourceID string) error
+	ListSources(ctx context.Context, opts ListOptions) ([]Source, error)
+	GetSourceStatus(ctx context.Context, sourceID string) (*ConnectorStatus, error)
+	TriggerSourceSnapshot(ctx context.Context, sourceID string, reqPayload SourceSnapshot) (*SourceSnapshot, error)
+	GetSourceSnapshot(ctx context.Context, sourceID, snapshotID string) (*SourceSnapshot, error)
 
 	// Destination APIs
 	CreateDestination(ctx context.Context, reqPayload Destination) (*Destination, error)
 	UpdateDestination(ctx context.Context, destinationID string, reqPayload Destination) (*Destination, error)
 	GetDestination(ctx context.Context, destinationID string) (*Destination, error)
 	DeleteDestination(ctx context.Context, destinationID string) error
+	ListDestinations(ctx context.Context, opts ListOptions) ([]Destination, error)
+	GetDestinationStatus(ctx context.Context, destinationID string) (*ConnectorStatus, error)
 
 	// Pipeline APIs
 	CreatePipeline(ctx context.Context, reqPayload Pipeline) (*Pipeline, error)
 	UpdatePipeline(ctx context.Context, pipelineID string, reqPayload Pipeline) (*Pipeline, error)
 	GetPipeline(ctx context.Context, pipelineID string) (*Pipeline, error)
 	DeletePipeline(ctx context.Context, pipelineID string) error
+	ListPipelines(ctx context.Context, opts ListOptions) ([]Pipeline, error)
+	GetPipelineStatus(ctx context.Context, pipelineID string) (*ConnectorStatus, error)
+	PausePipeline(ctx context.Context, pipelineID string) error
+	ResumePipeline(ctx context.Context, pipelineID string) error
+	RestartPipeline(ctx context.Context, pipelineID string) error
 
 	// Transform APIs
+	CreateTransform(ctx context.Context, reqPayload Transform) (*Transform, error)
+	UpdateTransform(ctx context.Context, transformID string, reqPayload Transform) (*Transform, error)
 	GetTransform(ctx context.Context, transformID string) (*Transform, error)
+	DeleteTransform(ctx context.Context, transformID string) error
+	ListTransforms(ctx context.Context, opts ListOptions) ([]Transform, error)
 
 	// Tags APIs
+	CreateTag(ctx context.Context, reqPayload TagPayload) (*Tag, error)
+	UpdateTag(ctx context.Context, tagID string, reqPayload TagPayload) (*Tag, error)
 	GetTag(ctx context.Context, TagID string) (*Tag, error)
+	DeleteTag(ctx context.Context, tagID string) error
+	ListTags(ctx context.Context, opts ListOptions) ([]Tag, error)
 
 	// Topic APIs
+	CreateTopic(ctx context.Context, reqPayload Topic) (*Topic, error)
 	GetTopic(ctx context.Context, TopicID string) (*Topic, error)
 	UpdateTopic(ctx context.Context, TopicID string, reqPayload Topic) (*Topic, error)
+	DeleteTopic(ctx context.Context, TopicID string) error
+	ListTopics(ctx context.Context, opts ListOptions) ([]Topic, error)
 }
 
 type APIErrorResponse struct {
@@ -53,38 +75,174 @@ type APIErrorResponse struct {
 
 type Config struct {
 	BaseURL string `mapstructure:"base_url"`
+
+	// MaxRetries is the number of times a failed request is retried before
+	// giving up. Zero disables retries.
+	MaxRetries int `mapstructure:"max_retries"`
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between
+	// two attempts. A Retry-After header sent by the API is honored up to RetryWaitMax.
+	RetryWaitMin time.Duration `mapstructure:"retry_wait_min"`
+	RetryWaitMax time.Duration `mapstructure:"retry_wait_max"`
+
+	// MaxRequestsPerSecond caps the rate of API calls, retries included.
+	// Zero means no limit.
+	MaxRequestsPerSecond float64 `mapstructure:"max_requests_per_second"`
+	// MaxConcurrentRequests caps the number of API calls in flight. Zero
+	// means no limit.
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests"`
+
+	// HTTPProxy overrides the proxy otherwise taken from the HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables.
+	HTTPProxy string `mapstructure:"http_proxy"`
+	// CACertFile and CACertPEM add PEM-encoded certificate authorities to the
+	// system pool, e.g. for a TLS-inspecting corporate proxy.
+	CACertFile string `mapstructure:"ca_cert_file"`
+	CACertPEM  string `mapstructure:"ca_cert_pem"`
+	// ClientCert and ClientKey are the PEM-encoded certificate and key
+	// presented for mutual TLS.
+	ClientCert         string `mapstructure:"client_cert"`
+	ClientKey          string `mapstructure:"client_key"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+	// RequestTimeout bounds every attempt of an API call. Zero means no
+	// timeout.
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	// TokenTimeout bounds a token exchange, retries included. Defaults to
+	// DefaultTokenTimeout.
+	TokenTimeout time.Duration `mapstructure:"token_timeout"`
+
+	// UserAgent is sent with every call, see UserAgent(). Defaults to
+	// DefaultUserAgent.
+	UserAgent string `mapstructure:"user_agent"`
+	// ClientInfo, when set, is sent in the X-Streamkap-Client header so
+	// support can tell which provider and Terraform versions made a call.
+	ClientInfo string `mapstructure:"client_info"`
+}
+
+const DefaultUserAgent = "terraform-provider-streamkap"
+
+// UserAgent builds the User-Agent of the provider, e.g.
+// "terraform-provider-streamkap/2.2.0 terraform/1.9.5 ci-job/1234".
+func UserAgent(providerVersion, terraformVersion, suffix string) string {
+	userAgent := DefaultUserAgent
+	if providerVersion != "" {
+		userAgent += "/" + providerVersion
+	}
+	if terraformVersion != "" {
+		userAgent += " terraform/" + terraformVersion
+	}
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// ClientInfo builds the X-Streamkap-Client header value, e.g.
+// "provider=2.2.0; terraform=1.9.5".
+func ClientInfo(providerVersion, terraformVersion string) string {
+	info := []string{}
+	if providerVersion != "" {
+		info = append(info, "provider="+providerVersion)
+	}
+	if terraformVersion != "" {
+		info = append(info, "terraform="+terraformVersion)
+	}
+	return strings.Join(info, "; ")
 }
 
 type streamkapAPI struct {
 	cfg    *Config
 	client *http.Client
-	token  *Token
+
+	// limiter and inFlight throttle the calls, both are nil when unlimited.
+	limiter  *rateLimiter
+	inFlight chan struct{}
+
+	// tokenMu guards the token, the credentials used to renew it and the
+	// refresh in progress. It is never held during a request.
+	tokenMu     sync.Mutex
+	token       *Token
+	tokenExpiry time.Time
+	clientID    string
+	secret      string
+	refresh     *tokenRefresh
 }
 
-func NewClient(cfg *Config) StreamkapAPI {
-	return &streamkapAPI{
-		cfg:    cfg,
-		client: http.DefaultClient,
+func NewClient(cfg *Config) (StreamkapAPI, error) {
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
+	if cfg.TokenTimeout <= 0 {
+		cfg.TokenTimeout = DefaultTokenTimeout
+	}
+	if cfg.RetryWaitMin <= 0 {
+		cfg.RetryWaitMin = DefaultRetryWaitMin
+	}
+	if cfg.RetryWaitMax < cfg.RetryWaitMin {
+		cfg.RetryWaitMax = max(DefaultRetryWaitMax, cfg.RetryWaitMin)
 	}
-}
 
-func (s *streamkapAPI) SetToken(token *Token) {
-	s.token = token
+	client, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &streamkapAPI{
+		cfg:     cfg,
+		client:  client,
+		limiter: newRateLimiter(cfg.MaxRequestsPerSecond),
+	}
+	if cfg.MaxConcurrentRequests > 0 {
+		s.inFlight = make(chan struct{}, cfg.MaxConcurrentRequests)
+	}
+
+	return s, nil
 }
 
 func (s *streamkapAPI) doRequest(ctx context.Context, req *http.Request, result interface{}) error {
+	ctx = s.maskSecrets(ctx)
+
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", s.cfg.UserAgent)
+	if s.cfg.ClientInfo != "" {
+		req.Header.Set("X-Streamkap-Client", s.cfg.ClientInfo)
+	}
 
-	if s.token != nil {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.token.AccessToken))
+	var token *Token
+	if !isAuthRequest(req) {
+		var err error
+		token, err = s.accessToken(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if token != nil {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
 	}
 
-	resp, err := s.client.Do(req)
+	resp, body, err := s.doWithRetry(ctx, req)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+
+	// The token may have been revoked or expired early: renew it once and
+	// replay the request.
+	if resp.StatusCode == http.StatusUnauthorized && token != nil {
+		tflog.Debug(ctx, fmt.Sprintf("%s %s returned %d, refreshing access token", req.Method, req.URL, resp.StatusCode))
+		token, err = s.refreshAccessToken(ctx, token)
+		if err != nil {
+			return err
+		}
+		replay, err := rewindRequest(req, 1)
+		if err != nil {
+			return err
+		}
+		replay.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+		resp, body, err = s.doWithRetry(ctx, replay)
+		if err != nil {
+			return err
+		}
+	}
 
 	requestID := resp.Header.Get("X-Request-Id")
 	if requestID != "" {
@@ -92,33 +250,36 @@ func (s *streamkapAPI) doRequest(ctx context.Context, req *http.Request, result
 			req.Method, req.URL, resp.StatusCode, requestID))
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("%s %s: failed to read response body (status %d): %w",
-			req.Method, req.URL, resp.StatusCode, err)
-	}
-
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		var apiErr APIErrorResponse
-		if jsonErr := json.Unmarshal(body, &apiErr); jsonErr == nil && apiErr.Detail != "" {
-			return errors.New(withRequestID(apiErr.Detail, requestID))
+		apiErr := &Error{
+			StatusCode: resp.StatusCode,
+			RequestID:  requestID,
+			Method:     req.Method,
+			URL:        req.URL.String(),
+		}
+		var errResp APIErrorResponse
+		if jsonErr := json.Unmarshal(body, &errResp); jsonErr == nil && errResp.Detail != "" {
+			apiErr.Detail = errResp.Detail
+			return apiErr
 		}
 		tflog.Debug(ctx,
 			fmt.Sprintf("%s %s returned %d with non-JSON or empty error body",
 				req.Method, req.URL, resp.StatusCode),
 		)
-		return errors.New(withRequestID(
-			fmt.Sprintf("unexpected %d %s from %s %s: %s",
-				resp.StatusCode,
-				http.StatusText(resp.StatusCode),
-				req.Method,
-				req.URL,
-				truncateBody(body, errorBodySnippetLimit),
-			),
-			requestID,
-		))
+		apiErr.Body = truncateBody(body, errorBodySnippetLimit)
+		return apiErr
 	}
 
+	tflog.Debug(ctx, fmt.Sprintf("%s %s response", req.Method, req.URL), map[string]any{
+		"status": resp.StatusCode,
+		"body":   redactBody(body),
+	})
+
+	// Action endpoints such as /pipelines/{id}/pause may answer 202 or
+	// 204 without a body.
+	if strings.TrimSpace(string(body)) == "" {
+		return nil
+	}
 	if err := json.Unmarshal(body, result); err != nil {
 		return err
 	}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *streamkapAPI {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{
+		BaseURL:      server.URL,
+		MaxRetries:   3,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 5 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	return client.(*streamkapAPI)
+}
+
+func TestDoRequestRetriesUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{{ID: "src"}}})
+	})
+
+	source, err := client.GetSource(context.Background(), "src")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if source.ID != "src" {
+		t.Errorf("expected source src, got %q", source.ID)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestDoRequestGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := client.GetSource(context.Background(), "src")
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected 502 error, got %v", err)
+	}
+	if got := calls.Load(); got != 4 {
+		t.Errorf("expected 4 calls, got %d", got)
+	}
+}
+
+func TestDoRequestReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(Source{ID: "src"})
+	})
+
+	_, err := client.UpdateSource(context.Background(), "src", Source{Name: "replayed"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || !strings.Contains(bodies[1], "replayed") {
+		t.Errorf("expected identical bodies on both attempts, got %q", bodies)
+	}
+}
+
+func TestDoRequestPostRetriesOnlyWhenSafe(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := client.CreateSource(context.Background(), Source{Name: "once"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected POST to be sent once, got %d calls", got)
+	}
+
+	calls.Store(0)
+	if _, err := client.GetAccessToken(context.Background(), "id", "secret"); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := calls.Load(); got != 4 {
+		t.Errorf("expected token exchange to be retried, got %d calls", got)
+	}
+}
+
+func TestDoRequestPostRetriesTooManyRequests(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(Source{ID: "src"})
+	})
+
+	source, err := client.CreateSource(context.Background(), Source{Name: "throttled"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if source.ID != "src" {
+		t.Errorf("expected source src, got %q", source.ID)
+	}
+}
+
+func TestDoRequestStopsOnCancel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	// Let the Retry-After wait run past the deadline.
+	client.cfg.RetryWaitMax = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetSource(ctx, "src")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected cancellation to interrupt the wait, took %s", elapsed)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	client := &streamkapAPI{cfg: &Config{
+		RetryWaitMin: time.Second,
+		RetryWaitMax: 8 * time.Second,
+	}}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := client.retryWait(context.Background(), attempt, nil)
+		if wait < time.Second || wait > 8*time.Second {
+			t.Errorf("attempt %d: wait %s out of bounds", attempt, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if wait := client.retryWait(context.Background(), 0, resp); wait != 5*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %s", wait)
+	}
+
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"3600"}}}
+	if wait := client.retryWait(context.Background(), 0, resp); wait != 8*time.Second {
+		t.Errorf("expected Retry-After to be capped at RetryWaitMax, got %s", wait)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := map[string]struct {
+		value string
+		ok    bool
+	}{
+		"seconds":   {value: "3", ok: true},
+		"http date": {value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), ok: true},
+		"empty":     {value: "", ok: false},
+		"negative":  {value: "-1", ok: false},
+		"garbage":   {value: "soon", ok: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := parseRetryAfter(tc.value); ok != tc.ok {
+				t.Errorf("parseRetryAfter(%q) ok = %v, want %v", tc.value, ok, tc.ok)
+			}
+		})
+	}
+}
+
+func TestDoRequestSendsClientHeaders(t *testing.T) {
+	var userAgent, clientInfo string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		clientInfo = r.Header.Get("X-Streamkap-Client")
+		json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{{ID: "src"}}})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{
+		BaseURL:    server.URL,
+		UserAgent:  UserAgent("2.2.0", "1.9.5", "ci-job/1234"),
+		ClientInfo: ClientInfo("2.2.0", "1.9.5"),
+	})
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	if _, err := client.GetSource(context.Background(), "src"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := "terraform-provider-streamkap/2.2.0 terraform/1.9.5 ci-job/1234"; userAgent != want {
+		t.Errorf("User-Agent = %q, want %q", userAgent, want)
+	}
+	if want := "provider=2.2.0; terraform=1.9.5"; clientInfo != want {
+		t.Errorf("X-Streamkap-Client = %q, want %q", clientInfo, want)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	cases := []struct {
+		providerVersion, terraformVersion, suffix, want string
+	}{
+		{"", "", "", "terraform-provider-streamkap"},
+		{"dev", "", "", "terraform-provider-streamkap/dev"},
+		{"2.2.0", "1.9.5", "", "terraform-provider-streamkap/2.2.0 terraform/1.9.5"},
+		{"2.2.0", "1.9.5", "  job-42 ", "terraform-provider-streamkap/2.2.0 terraform/1.9.5 job-42"},
+	}
+
+	for _, tc := range cases {
+		if got := UserAgent(tc.providerVersion, tc.terraformVersion, tc.suffix); got != tc.want {
+			t.Errorf("UserAgent(%q, %q, %q) = %q, want %q", tc.providerVersion, tc.terraformVersion, tc.suffix, got, tc.want)
+		}
+	}
+}
+
+func TestDoRequestAcceptsEmptyBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/pipelines/p1/pause" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.PausePipeline(context.Background(), "p1"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -11,18 +11,14 @@ import (
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/constants"
 )
 
-type GetDestinationResponse struct {
-	Total    int           `json:"total"`
-	PageSize int           `json:"page_size"`
-	Page     int           `json:"page"`
-	Result   []Destination `json:"result"`
-}
+type GetDestinationResponse = Page[Destination]
 
 type Destination struct {
 	ID        string         `json:"id"`
 	Name      string         `json:"name"`
 	Connector string         `json:"connector"`
 	Config    map[string]any `json:"config"`
+	Tags      []string       `json:"tags,omitempty"`
 }
 
 func (s *streamkapAPI) CreateDestination(ctx context.Context, reqPayload Destination) (*Destination, error) {
@@ -44,7 +40,7 @@ func (s *streamkapAPI) CreateDestination(ctx context.Context, reqPayload Destina
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/destinations?secret_returned=true", bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/destinations"+secretsQuery(ctx), bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +51,7 @@ func (s *streamkapAPI) CreateDestination(ctx context.Context, reqPayload Destina
 			"\tBody: %s",
 		req.Method,
 		req.URL.String(),
-		payload,
+		redactBody(payload),
 	))
 	var resp Destination
 	err = s.doRequest(ctx, req, &resp)
@@ -67,7 +63,7 @@ func (s *streamkapAPI) CreateDestination(ctx context.Context, reqPayload Destina
 }
 
 func (s *streamkapAPI) GetDestination(ctx context.Context, destinationID string) (*Destination, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.BaseURL+"/destinations/"+destinationID+"?secret_returned=true", http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.BaseURL+"/destinations/"+destinationID+secretsQuery(ctx), http.NoBody)
 	if err != nil {
 		return nil, err
 	}
@@ -85,14 +81,14 @@ func (s *streamkapAPI) GetDestination(ctx context.Context, destinationID string)
 	}
 
 	if len(resp.Result) == 0 {
-		return nil, nil
+		return nil, notFound("destination", destinationID)
 	}
 
 	return &resp.Result[0], nil
 }
 
 func (s *streamkapAPI) DeleteDestination(ctx context.Context, destinationID string) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.cfg.BaseURL+"/destinations/"+destinationID+"?secret_returned=true", http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.cfg.BaseURL+"/destinations/"+destinationID+secretsQuery(ctx), http.NoBody)
 	if err != nil {
 		return err
 	}
@@ -118,7 +114,7 @@ func (s *streamkapAPI) UpdateDestination(ctx context.Context, destinationID stri
 		return nil, err
 	}
 	req, err := http.NewRequestWithContext(
-		ctx, http.MethodPut, s.cfg.BaseURL+"/destinations/"+destinationID+"?secret_returned=true", bytes.NewBuffer(payload))
+		ctx, http.MethodPut, s.cfg.BaseURL+"/destinations/"+destinationID+secretsQuery(ctx), bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +125,7 @@ func (s *streamkapAPI) UpdateDestination(ctx context.Context, destinationID stri
 			"\tBody: %s",
 		req.Method,
 		req.URL.String(),
-		payload,
+		redactBody(payload),
 	))
 	var resp Destination
 	err = s.doRequest(ctx, req, &resp)
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinels matched by errors.Is against the errors returned by StreamkapAPI.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+)
+
+// Error is returned when the Streamkap API answers with a non-2xx status.
+type Error struct {
+	StatusCode int
+	RequestID  string
+	Method     string
+	URL        string
+	// Detail is the error message sent by the API, if any.
+	Detail string
+	// Body is a truncated snippet of the response body, used when the API
+	// did not send a detail, e.g. for gateway or WAF error pages.
+	Body string
+}
+
+func (e *Error) Error() string {
+	if e.Detail != "" {
+		return withRequestID(e.Detail, e.RequestID)
+	}
+	return withRequestID(
+		fmt.Sprintf("unexpected %d %s from %s %s: %s",
+			e.StatusCode,
+			http.StatusText(e.StatusCode),
+			e.Method,
+			e.URL,
+			e.Body,
+		),
+		e.RequestID,
+	)
+}
+
+// Is maps the HTTP status of the error to the sentinel errors.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	}
+	return false
+}
+
+// notFound is returned by the getters when the API answers successfully but
+// without any matching object.
+func notFound(kind, id string) error {
+	return fmt.Errorf("%s %s: %w", kind, id, ErrNotFound)
+}
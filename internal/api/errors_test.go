@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	cases := map[int]error{
+		http.StatusNotFound:            ErrNotFound,
+		http.StatusUnauthorized:        ErrUnauthorized,
+		http.StatusForbidden:           ErrUnauthorized,
+		http.StatusConflict:            ErrConflict,
+		http.StatusTooManyRequests:     ErrRateLimited,
+		http.StatusBadRequest:          ErrValidation,
+		http.StatusUnprocessableEntity: ErrValidation,
+	}
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrConflict, ErrRateLimited, ErrValidation}
+
+	for status, want := range cases {
+		err := error(&Error{StatusCode: status})
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == want) {
+				t.Errorf("status %d: errors.Is(%v) = %v", status, sentinel, got)
+			}
+		}
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	withDetail := &Error{StatusCode: http.StatusBadRequest, Detail: "name is required", RequestID: "abc"}
+	if got, want := withDetail.Error(), "name is required (request_id=abc)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	withBody := &Error{StatusCode: http.StatusGatewayTimeout, Method: "GET", URL: "https://api/sources", Body: "<html>"}
+	if got, want := withBody.Error(), "unexpected 504 Gateway Timeout from GET https://api/sources: <html>"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestGetSourceNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sources/empty":
+			json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(APIErrorResponse{Detail: "Source not found"})
+		}
+	})
+
+	for _, id := range []string{"empty", "missing"} {
+		source, err := client.GetSource(context.Background(), id)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound, got %v", id, err)
+		}
+		if source != nil {
+			t.Errorf("%s: expected no source, got %+v", id, source)
+		}
+	}
+
+	_, err := client.GetSource(context.Background(), "missing")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Method != http.MethodGet || apiErr.Detail != "Source not found" {
+		t.Errorf("unexpected error fields: %+v", apiErr)
+	}
+}
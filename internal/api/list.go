@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const DefaultPageSize = 100
+
+// ListOptions filters the objects returned by the List APIs. Empty fields
+// are not sent.
+type ListOptions struct {
+	// Connector is the connector code, e.g. "postgresql".
+	Connector string
+	// Name matches the object name.
+	Name string
+	// TagID only returns objects carrying this tag.
+	TagID string
+	// CreatedFrom is the origin of the objects, e.g. constants.TERRAFORM.
+	CreatedFrom string
+	// SourceID only returns the topics of this source.
+	SourceID string
+	// PageSize is the number of objects fetched per call. Defaults to
+	// DefaultPageSize.
+	PageSize int
+}
+
+func (o ListOptions) query() url.Values {
+	q := url.Values{}
+	if o.Connector != "" {
+		q.Set("connector", o.Connector)
+	}
+	if o.Name != "" {
+		q.Set("name", o.Name)
+	}
+	if o.TagID != "" {
+		q.Set("tag_ids", o.TagID)
+	}
+	if o.CreatedFrom != "" {
+		q.Set("created_from", o.CreatedFrom)
+	}
+	if o.SourceID != "" {
+		q.Set("source_id", o.SourceID)
+	}
+	return q
+}
+
+// Page is a page of objects as returned by the list endpoints.
+type Page[T any] struct {
+	Total    int `json:"total"`
+	PageSize int `json:"page_size"`
+	Page     int `json:"page"`
+	Result   []T `json:"result"`
+}
+
+// pager walks the pages of a list endpoint.
+type pager[T any] struct {
+	s        *streamkapAPI
+	path     string
+	query    url.Values
+	pageSize int
+
+	page int
+	seen int
+	done bool
+}
+
+func newPager[T any](s *streamkapAPI, path string, query url.Values, pageSize int) *pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &pager[T]{
+		s:        s,
+		path:     path,
+		query:    query,
+		pageSize: pageSize,
+	}
+}
+
+// Next returns the objects of the next page, or false once every page has
+// been walked.
+func (p *pager[T]) Next(ctx context.Context) ([]T, bool, error) {
+	if p.done {
+		return nil, false, nil
+	}
+	p.page++
+
+	u, err := url.Parse(p.s.cfg.BaseURL)
+	if err != nil {
+		return nil, false, err
+	}
+	u = u.JoinPath(p.path)
+	q := url.Values{}
+	for key, values := range p.query {
+		q[key] = values
+	}
+	q.Set("page", strconv.Itoa(p.page))
+	q.Set("page_size", strconv.Itoa(p.pageSize))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return nil, false, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"List request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n",
+		req.Method,
+		req.URL.String(),
+	))
+	var resp Page[T]
+	err = p.s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The API may cap the page size below the requested one.
+	pageSize := resp.PageSize
+	if pageSize <= 0 {
+		pageSize = p.pageSize
+	}
+	p.seen += len(resp.Result)
+	if len(resp.Result) == 0 || len(resp.Result) < pageSize || (resp.Total > 0 && p.seen >= resp.Total) {
+		p.done = true
+	}
+	if len(resp.Result) == 0 {
+		return nil, false, nil
+	}
+
+	return resp.Result, true, nil
+}
+
+// listAll walks every page of a list endpoint.
+func listAll[T any](ctx context.Context, s *streamkapAPI, path string, query url.Values, pageSize int) ([]T, error) {
+	res := []T{}
+	p := newPager[T](s, path, query, pageSize)
+	for {
+		items, ok, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return res, nil
+		}
+		res = append(res, items...)
+	}
+}
+
+func (s *streamkapAPI) ListSources(ctx context.Context, opts ListOptions) ([]Source, error) {
+	return listAll[Source](ctx, s, "sources", opts.query(), opts.PageSize)
+}
+
+func (s *streamkapAPI) ListDestinations(ctx context.Context, opts ListOptions) ([]Destination, error) {
+	return listAll[Destination](ctx, s, "destinations", opts.query(), opts.PageSize)
+}
+
+func (s *streamkapAPI) ListPipelines(ctx context.Context, opts ListOptions) ([]Pipeline, error) {
+	return listAll[Pipeline](ctx, s, "pipelines", opts.query(), opts.PageSize)
+}
+
+func (s *streamkapAPI) ListTransforms(ctx context.Context, opts ListOptions) ([]Transform, error) {
+	query := opts.query()
+	query.Set("unwind_topics", "false")
+	return listAll[Transform](ctx, s, "transforms", query, opts.PageSize)
+}
+
+func (s *streamkapAPI) ListTopics(ctx context.Context, opts ListOptions) ([]Topic, error) {
+	return listAll[Topic](ctx, s, "topics", opts.query(), opts.PageSize)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestListSourcesWalksEveryPage(t *testing.T) {
+	sources := []Source{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+	var pages []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("connector") != "postgresql" || q.Get("tag_ids") != "tag" || q.Get("created_from") != "terraform" {
+			t.Errorf("unexpected filters %s", r.URL.RawQuery)
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+		pageSize, _ := strconv.Atoi(q.Get("page_size"))
+		pages = append(pages, q.Get("page"))
+
+		start := min((page-1)*pageSize, len(sources))
+		end := min(start+pageSize, len(sources))
+		json.NewEncoder(w).Encode(GetSourceResponse{
+			Total:    len(sources),
+			PageSize: pageSize,
+			Page:     page,
+			Result:   sources[start:end],
+		})
+	})
+
+	got, err := client.ListSources(context.Background(), ListOptions{
+		Connector:   "postgresql",
+		TagID:       "tag",
+		CreatedFrom: "terraform",
+		PageSize:    2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != len(sources) {
+		t.Fatalf("expected %d sources, got %d", len(sources), len(got))
+	}
+	for i := range sources {
+		if got[i].ID != sources[i].ID {
+			t.Errorf("expected source %s at %d, got %s", sources[i].ID, i, got[i].ID)
+		}
+	}
+	if len(pages) != 3 {
+		t.Errorf("expected 3 pages to be fetched, got %v", pages)
+	}
+}
+
+func TestListStopsOnCappedPageSize(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// The server ignores the requested page size and returns 1 per page.
+		if r.URL.Query().Get("page") == "1" {
+			json.NewEncoder(w).Encode(GetPipelineResponse{PageSize: 1, Result: []Pipeline{{ID: "p1"}}})
+			return
+		}
+		json.NewEncoder(w).Encode(GetPipelineResponse{PageSize: 1, Result: []Pipeline{}})
+	})
+
+	got, err := client.ListPipelines(context.Background(), ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 1 || calls != 2 {
+		t.Errorf("expected 1 pipeline in 2 calls, got %d in %d", len(got), calls)
+	}
+}
+
+func TestListTags(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tags" || r.URL.Query().Get("name") != "prod" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(GetTagResponse{Tags: []Tag{{ID: "t1", Name: "prod"}}})
+	})
+
+	tags, err := client.ListTags(context.Background(), ListOptions{Name: "prod"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tags) != 1 || tags[0].ID != "t1" {
+		t.Errorf("unexpected tags %+v", tags)
+	}
+}
@@ -21,12 +21,7 @@ type Pipeline struct {
 	Tags              []string             `json:"tags"`
 }
 
-type GetPipelineResponse struct {
-	Total    int        `json:"total"`
-	PageSize int        `json:"page_size"`
-	Page     int        `json:"page"`
-	Result   []Pipeline `json:"result"`
-}
+type GetPipelineResponse = Page[Pipeline]
 
 type PipelineSource struct {
 	ID        string   `json:"id"`
@@ -79,7 +74,7 @@ func (s *streamkapAPI) CreatePipeline(ctx context.Context, reqPayload Pipeline)
 			"\tBody: %s",
 		req.Method,
 		req.URL.String(),
-		payload,
+		redactBody(payload),
 	))
 	var resp Pipeline
 	err = s.doRequest(ctx, req, &resp)
@@ -109,7 +104,7 @@ func (s *streamkapAPI) GetPipeline(ctx context.Context, pipelineID string) (*Pip
 	}
 
 	if len(resp.Result) == 0 {
-		return nil, nil
+		return nil, notFound("pipeline", pipelineID)
 	}
 
 	return &resp.Result[0], nil
@@ -153,7 +148,7 @@ func (s *streamkapAPI) UpdatePipeline(ctx context.Context, pipelineID string, re
 			"\tBody: %s",
 		req.Method,
 		req.URL.String(),
-		payload,
+		redactBody(payload),
 	))
 	var resp Pipeline
 	err = s.doRequest(ctx, req, &resp)
@@ -163,3 +158,39 @@ func (s *streamkapAPI) UpdatePipeline(ctx context.Context, pipelineID string, re
 
 	return &resp, nil
 }
+
+func (s *streamkapAPI) PausePipeline(ctx context.Context, pipelineID string) error {
+	return s.pipelineAction(ctx, "PausePipeline", pipelineID, "pause")
+}
+
+func (s *streamkapAPI) ResumePipeline(ctx context.Context, pipelineID string) error {
+	return s.pipelineAction(ctx, "ResumePipeline", pipelineID, "resume")
+}
+
+func (s *streamkapAPI) RestartPipeline(ctx context.Context, pipelineID string) error {
+	return s.pipelineAction(ctx, "RestartPipeline", pipelineID, "restart")
+}
+
+// pipelineAction posts to one of the pipeline action endpoints, e.g.
+// /pipelines/{id}/pause.
+func (s *streamkapAPI) pipelineAction(ctx context.Context, name, pipelineID, action string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/pipelines/"+pipelineID+"/"+action, http.NoBody)
+	if err != nil {
+		return err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"%s request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n",
+		name,
+		req.Method,
+		req.URL.String(),
+	))
+	var resp any
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxRequestsPerSecond  = 10
+	DefaultMaxConcurrentRequests = 5
+)
+
+// rateLimiter is a token bucket allowing rate requests per second on
+// average, with bursts of up to burst requests.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns nil, i.e. no limit, when rate is not positive.
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	burst := math.Max(1, math.Ceil(rate))
+	return &rateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// Reserve a token, possibly borrowing it from the future.
+	l.tokens--
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// Give the reservation back to the requests still queued.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// throttle waits for the rate limiter and a free concurrency slot before a
+// request is sent. The returned function releases the slot.
+func (s *streamkapAPI) throttle(ctx context.Context) (func(), error) {
+	if err := s.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	if s.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.inFlight <- struct{}{}:
+		return func() { <-s.inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
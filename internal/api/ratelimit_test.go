@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	limiter := newRateLimiter(50)
+
+	start := time.Now()
+	// The first 50 calls use the burst, the next 10 wait 20ms each.
+	for i := 0; i < 60; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected calls over the burst to be delayed, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterCancelled(t *testing.T) {
+	limiter := newRateLimiter(1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected a cancelled wait to return immediately, took %s", elapsed)
+	}
+}
+
+func TestDoRequestConcurrencyCap(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{{ID: "src"}}})
+	})
+	client.inFlight = make(chan struct{}, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetSource(context.Background(), "src"); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("expected at most 2 calls in flight, got %d", got)
+	}
+}
+
+func TestDoRequestCancelledWhileQueued(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{{ID: "src"}}})
+	})
+	client.inFlight = make(chan struct{}, 1)
+	// Hold the only slot.
+	client.inFlight <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err := client.GetSource(ctx, "src")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the queued call to be cancelled, got %v", err)
+	}
+}
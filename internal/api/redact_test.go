@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	sensitive := []string{
+		"database.password",
+		"connection.password",
+		"snowflake.private.key",
+		"snowflake.private.key.passphrase",
+		"aws.secret.key",
+		"aws.secret.access.key",
+		"iceberg.catalog.s3.secret-access-key",
+		"databricks.token",
+		"mongodb.connection.string.user.defined",
+		"secret",
+		"accessToken",
+		// Resource attribute names
+		"database_password",
+		"snowflake_private_key",
+		"aws_secret_key",
+		"mongodb_connection_string",
+	}
+	for _, key := range sensitive {
+		if !IsSensitiveKey(key) {
+			t.Errorf("expected %q to be sensitive", key)
+		}
+	}
+
+	for _, key := range []string{"database.user", "aws.access.key.id", "slot.name", "ssh.host", "name"} {
+		if IsSensitiveKey(key) {
+			t.Errorf("expected %q not to be sensitive", key)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := []byte(`{"name":"pg","config":{"database.user":"admin","database.password":"hunter2","nested":[{"databricks.token":"dapi"}]}}`)
+
+	redacted := redactBody(body)
+	for _, secret := range []string{"hunter2", "dapi"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("expected %q to be redacted from %s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, `"database.user":"admin"`) {
+		t.Errorf("expected non-sensitive values to be kept, got %s", redacted)
+	}
+
+	if got := redactBody([]byte("<html>")); strings.Contains(got, "html") {
+		t.Errorf("expected non-JSON body to be summarized, got %s", got)
+	}
+}
+
+func TestDoRequestLogsAreRedacted(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/access-token" {
+			json.NewEncoder(w).Encode(Token{AccessToken: "live-access-token", RefreshToken: "live-refresh-token"})
+			return
+		}
+		json.NewEncoder(w).Encode(Source{ID: "src", Config: map[string]any{"database.password": "hunter2"}})
+	})
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+
+	token, err := client.requestAccessToken(ctx, "id", "client-secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	client.SetToken(token)
+
+	_, err = client.CreateSource(ctx, Source{Name: "pg", Config: map[string]any{"database.password": "hunter2"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if logs.Len() == 0 {
+		t.Fatal("expected debug logs")
+	}
+	for _, secret := range []string{"hunter2", "client-secret", "live-access-token", "live-refresh-token"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("expected %q to be masked in logs:\n%s", secret, logs.String())
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DefaultMaxRetries   = 4
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+type retrySafeKey struct{}
+
+// withRetrySafe marks the requests built from ctx as safe to retry even when
+// their method is not idempotent, e.g. a POST that has no side effect.
+func withRetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+func isRetrySafe(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	safe, _ := req.Context().Value(retrySafeKey{}).(bool)
+	return safe
+}
+
+// doWithRetry sends req, retrying throttled, unavailable and dropped requests
+// with capped exponential backoff. It returns the last response, whose body
+// has already been read and closed.
+func (s *streamkapAPI) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		release, err := s.throttle(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		resp, err := s.client.Do(attemptReq)
+		var body []byte
+		if err == nil {
+			body, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				err = fmt.Errorf("%s %s: failed to read response body (status %d): %w",
+					req.Method, req.URL, resp.StatusCode, err)
+			}
+		}
+		release()
+
+		if attempt >= s.cfg.MaxRetries || !s.shouldRetry(req, resp, err) {
+			if err != nil {
+				return nil, nil, err
+			}
+			return resp, body, nil
+		}
+
+		wait := s.retryWait(ctx, attempt, resp)
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("%s %s failed: %s, retrying in %s (attempt %d/%d)",
+				req.Method, req.URL, err, wait, attempt+1, s.cfg.MaxRetries))
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("%s %s returned %d, retrying in %s (attempt %d/%d)",
+				req.Method, req.URL, resp.StatusCode, wait, attempt+1, s.cfg.MaxRetries))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rewindRequest returns the request to send for the given attempt, with a
+// fresh copy of the body for every retry.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("%s %s: request body cannot be replayed", req.Method, req.URL)
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retryReq := req.Clone(req.Context())
+	retryReq.Body = body
+	return retryReq, nil
+}
+
+func (s *streamkapAPI) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// A request that never reached the API can always be sent again.
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		if !isRetrySafe(req) {
+			return false
+		}
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF) ||
+			isTimeout(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// Throttled requests are rejected before they are processed.
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isRetrySafe(req)
+	}
+	return false
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryWait returns how long to wait before the next attempt. A Retry-After
+// header is honored up to RetryWaitMax, otherwise the wait grows
+// exponentially from RetryWaitMin up to RetryWaitMax with random jitter.
+func (s *streamkapAPI) retryWait(ctx context.Context, attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > s.cfg.RetryWaitMax {
+				tflog.Warn(ctx, fmt.Sprintf("Retry-After of %s exceeds retry_wait_max, waiting %s instead",
+					wait, s.cfg.RetryWaitMax))
+				return s.cfg.RetryWaitMax
+			}
+			return wait
+		}
+	}
+
+	wait := s.cfg.RetryWaitMax
+	if attempt < 32 {
+		if exp := s.cfg.RetryWaitMin << attempt; exp > 0 && exp < wait {
+			wait = exp
+		}
+	}
+
+	// Equal jitter: keep half of the wait and randomize the other half.
+	half := wait / 2
+	wait = half + time.Duration(rand.Int63n(int64(half)+1))
+	if wait < s.cfg.RetryWaitMin {
+		wait = s.cfg.RetryWaitMin
+	}
+	return wait
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,21 @@
+package api
+
+import "context"
+
+type withoutSecretsKey struct{}
+
+// WithoutSecrets returns a context asking the API not to return the secrets
+// of the sources and destinations created, read or updated with it, e.g. for
+// a resource whose secrets are write-only and must stay out of the state.
+func WithoutSecrets(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutSecretsKey{}, true)
+}
+
+// secretsQuery is the query string of the source and destination endpoints:
+// it asks for the secrets unless ctx comes from WithoutSecrets.
+func secretsQuery(ctx context.Context) string {
+	if without, _ := ctx.Value(withoutSecretsKey{}).(bool); without {
+		return ""
+	}
+	return "?secret_returned=true"
+}
@@ -11,18 +11,14 @@ import (
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/constants"
 )
 
-type GetSourceResponse struct {
-	Total    int      `json:"total"`
-	PageSize int      `json:"page_size"`
-	Page     int      `json:"page"`
-	Result   []Source `json:"result"`
-}
+type GetSourceResponse = Page[Source]
 
 type Source struct {
 	ID        string         `json:"id,omitempty"`
 	Name      string         `json:"name"`
 	Connector string         `json:"connector"`
 	Config    map[string]any `json:"config"`
+	Tags      []string       `json:"tags,omitempty"`
 }
 
 func (s *streamkapAPI) CreateSource(ctx context.Context, reqPayload Source) (*Source, error) {
@@ -44,7 +40,7 @@ func (s *streamkapAPI) CreateSource(ctx context.Context, reqPayload Source) (*So
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/sources?secret_returned=true", bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/sources"+secretsQuery(ctx), bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +51,7 @@ func (s *streamkapAPI) CreateSource(ctx context.Context, reqPayload Source) (*So
 			"\tBody: %s",
 		req.Method,
 		req.URL.String(),
-		payload,
+		redactBody(payload),
 	))
 	var resp Source
 	err = s.doRequest(ctx, req, &resp)
@@ -67,7 +63,7 @@ func (s *streamkapAPI) CreateSource(ctx context.Context, reqPayload Source) (*So
 }
 
 func (s *streamkapAPI) GetSource(ctx context.Context, sourceID string) (*Source, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.BaseURL+"/sources/"+sourceID+"?secret_returned=true", http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.BaseURL+"/sources/"+sourceID+secretsQuery(ctx), http.NoBody)
 	if err != nil {
 		return nil, err
 	}
@@ -85,14 +81,14 @@ func (s *streamkapAPI) GetSource(ctx context.Context, sourceID string) (*Source,
 	}
 
 	if len(resp.Result) == 0 {
-		return nil, nil
+		return nil, notFound("source", sourceID)
 	}
 
 	return &resp.Result[0], nil
 }
 
 func (s *streamkapAPI) DeleteSource(ctx context.Context, sourceID string) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.cfg.BaseURL+"/sources/"+sourceID+"?secret_returned=true", http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.cfg.BaseURL+"/sources/"+sourceID+secretsQuery(ctx), http.NoBody)
 	if err != nil {
 		return err
 	}
@@ -118,7 +114,7 @@ func (s *streamkapAPI) UpdateSource(ctx context.Context, sourceID string, reqPay
 		return nil, err
 	}
 	req, err := http.NewRequestWithContext(
-		ctx, http.MethodPut, s.cfg.BaseURL+"/sources/"+sourceID+"?secret_returned=true", bytes.NewBuffer(payload))
+		ctx, http.MethodPut, s.cfg.BaseURL+"/sources/"+sourceID+secretsQuery(ctx), bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +125,7 @@ func (s *streamkapAPI) UpdateSource(ctx context.Context, sourceID string, reqPay
 			"\tBody: %s",
 		req.Method,
 		req.URL.String(),
-		payload,
+		redactBody(payload),
 	))
 	var resp Source
 	err = s.doRequest(ctx, req, &resp)
@@ -139,3 +135,72 @@ func (s *streamkapAPI) UpdateSource(ctx context.Context, sourceID string, reqPay
 
 	return &resp, nil
 }
+
+// Source snapshot states reported by GetSourceSnapshot.
+const (
+	SnapshotStatusPending   = "PENDING"
+	SnapshotStatusRunning   = "RUNNING"
+	SnapshotStatusCompleted = "COMPLETED"
+	SnapshotStatusFailed    = "FAILED"
+)
+
+const SnapshotTypeIncremental = "incremental"
+
+// SourceSnapshot is an ad-hoc snapshot of some tables of a source, fired
+// through the source's signal collection.
+type SourceSnapshot struct {
+	ID     string   `json:"id,omitempty"`
+	Type   string   `json:"type"`
+	Tables []string `json:"tables"`
+	Status string   `json:"status,omitempty"`
+	// Error explains the failure, when Status is FAILED.
+	Error string `json:"error,omitempty"`
+}
+
+func (s *streamkapAPI) TriggerSourceSnapshot(ctx context.Context, sourceID string, reqPayload SourceSnapshot) (*SourceSnapshot, error) {
+	payload, err := json.Marshal(reqPayload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/sources/"+sourceID+"/snapshots", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"TriggerSourceSnapshot request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n"+
+			"\tBody: %s",
+		req.Method,
+		req.URL.String(),
+		redactBody(payload),
+	))
+	var resp SourceSnapshot
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *streamkapAPI) GetSourceSnapshot(ctx context.Context, sourceID, snapshotID string) (*SourceSnapshot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.BaseURL+"/sources/"+sourceID+"/snapshots/"+snapshotID, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"GetSourceSnapshot request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n",
+		req.Method,
+		req.URL.String(),
+	))
+	var resp SourceSnapshot
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
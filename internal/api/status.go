@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Connector states reported by the status endpoints.
+const (
+	ConnectorStatusRunning    = "RUNNING"
+	ConnectorStatusPaused     = "PAUSED"
+	ConnectorStatusFailed     = "FAILED"
+	ConnectorStatusStarting   = "STARTING"
+	ConnectorStatusUnassigned = "UNASSIGNED"
+)
+
+// ConnectorStatus is the health of the connector running a source,
+// destination or pipeline.
+type ConnectorStatus struct {
+	State string `json:"state"`
+	// Trace is the stack trace of the failure, when State is FAILED.
+	Trace string `json:"trace,omitempty"`
+}
+
+func (s *streamkapAPI) GetSourceStatus(ctx context.Context, sourceID string) (*ConnectorStatus, error) {
+	return s.getStatus(ctx, "GetSourceStatus", "/sources/"+sourceID+"/status")
+}
+
+func (s *streamkapAPI) GetDestinationStatus(ctx context.Context, destinationID string) (*ConnectorStatus, error) {
+	return s.getStatus(ctx, "GetDestinationStatus", "/destinations/"+destinationID+"/status")
+}
+
+func (s *streamkapAPI) GetPipelineStatus(ctx context.Context, pipelineID string) (*ConnectorStatus, error) {
+	return s.getStatus(ctx, "GetPipelineStatus", "/pipelines/"+pipelineID+"/status")
+}
+
+func (s *streamkapAPI) getStatus(ctx context.Context, name, path string) (*ConnectorStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.BaseURL+path, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"%s request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n",
+		name,
+		req.Method,
+		req.URL.String(),
+	))
+	var resp ConnectorStatus
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -1,12 +1,15 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/constants"
 )
 
 type GetTagResponse struct {
@@ -22,6 +25,103 @@ type Tag struct {
 	Custom      *bool    `json:"custom"`
 }
 
+// TagPayload is the body of the tag create and update calls.
+type TagPayload struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Type        []string `json:"type"`
+}
+
+func (s *streamkapAPI) CreateTag(ctx context.Context, reqPayload TagPayload) (*Tag, error) {
+	payload, err := json.Marshal(reqPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var payloadMap map[string]any
+	err = json.Unmarshal(payload, &payloadMap)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadMap["created_from"] = constants.TERRAFORM
+
+	payload, err = json.Marshal(payloadMap)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/tags", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"CreateTag request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n"+
+			"\tBody: %s",
+		req.Method,
+		req.URL.String(),
+		redactBody(payload),
+	))
+	var resp Tag
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *streamkapAPI) UpdateTag(ctx context.Context, tagID string, reqPayload TagPayload) (*Tag, error) {
+	payload, err := json.Marshal(reqPayload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, s.cfg.BaseURL+"/tags/"+tagID, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"UpdateTag request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n"+
+			"\tBody: %s",
+		req.Method,
+		req.URL.String(),
+		redactBody(payload),
+	))
+	var resp Tag
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *streamkapAPI) DeleteTag(ctx context.Context, tagID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.cfg.BaseURL+"/tags/"+tagID, http.NoBody)
+	if err != nil {
+		return err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"DeleteTag request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n",
+		req.Method,
+		req.URL.String(),
+	))
+	var resp Tag
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *streamkapAPI) GetTag(ctx context.Context, TagID string) (*Tag, error) {
 	url, err := url.Parse(s.cfg.BaseURL)
 	if err != nil {
@@ -51,8 +151,38 @@ func (s *streamkapAPI) GetTag(ctx context.Context, TagID string) (*Tag, error) {
 	}
 
 	if len(resp.Tags) == 0 {
-		return nil, nil
+		return nil, notFound("tag", TagID)
 	}
 
 	return &resp.Tags[0], nil
 }
+
+func (s *streamkapAPI) ListTags(ctx context.Context, opts ListOptions) ([]Tag, error) {
+	url, err := url.Parse(s.cfg.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	url = url.JoinPath("tags")
+	q := opts.query()
+	url.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"ListTags request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n",
+		req.Method,
+		req.URL.String(),
+	))
+	// Tags are not paginated.
+	var resp GetTagResponse
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Tags, nil
+}
@@ -8,19 +8,98 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/constants"
+)
+
+// Topic config keys managed by the topic resource.
+const (
+	TopicConfigRetentionMs        = "retention.ms"
+	TopicConfigCleanupPolicy      = "cleanup.policy"
+	TopicConfigMinCompactionLagMs = "min.compaction.lag.ms"
+	TopicConfigMaxCompactionLagMs = "max.compaction.lag.ms"
+	TopicConfigMinInsyncReplicas  = "min.insync.replicas"
 )
 
 type Topic struct {
-	TopicID               string      `json:"topic_id"`
-	PartitionCount        int         `json:"partition_count"`
+	TopicID           string            `json:"topic_id"`
+	Name              string            `json:"name,omitempty"`
+	SourceID          string            `json:"source_id,omitempty"`
+	PartitionCount    int               `json:"partition_count"`
+	ReplicationFactor int               `json:"replication_factor,omitempty"`
+	Config            map[string]string `json:"config,omitempty"`
 }
 
+func (s *streamkapAPI) CreateTopic(ctx context.Context, reqPayload Topic) (*Topic, error) {
+	payload, err := json.Marshal(reqPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var payloadMap map[string]any
+	err = json.Unmarshal(payload, &payloadMap)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadMap["created_from"] = constants.TERRAFORM
+
+	payload, err = json.Marshal(map[string]any{"payload": payloadMap})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/topics", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"CreateTopic request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n"+
+			"\tBody: %s",
+		req.Method,
+		req.URL.String(),
+		redactBody(payload),
+	))
+	var resp Topic
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *streamkapAPI) DeleteTopic(ctx context.Context, topicID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.cfg.BaseURL+"/topics/"+topicID, http.NoBody)
+	if err != nil {
+		return err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"DeleteTopic request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n",
+		req.Method,
+		req.URL.String(),
+	))
+	var resp any
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
 
 func (s *streamkapAPI) UpdateTopic(ctx context.Context, topicID string, reqPayload Topic) (*Topic, error) {
-	expectedPayload := map[string]map[string]int{
+	expectedPayload := map[string]map[string]any{
 		"payload": {},
 	}
 	expectedPayload["payload"]["partition_count"] = reqPayload.PartitionCount
+	// The replication factor is fixed at creation, only the config can change.
+	if len(reqPayload.Config) > 0 {
+		expectedPayload["payload"]["config"] = reqPayload.Config
+	}
 	payload, err := json.Marshal(expectedPayload)
 	if err != nil {
 		return nil, err
@@ -37,19 +116,19 @@ func (s *streamkapAPI) UpdateTopic(ctx context.Context, topicID string, reqPaylo
 			"\tBody: %s",
 		req.Method,
 		req.URL.String(),
-		payload,
+		redactBody(payload),
 	))
-	var rep any
-	err = s.doRequest(ctx, req, &rep)
+	var resp Topic
+	err = s.doRequest(ctx, req, &resp)
 	if err != nil {
 		return nil, err
 	}
 
-	return &reqPayload, nil
+	return &resp, nil
 }
 
 func (s *streamkapAPI) GetTopic(ctx context.Context, topicID string) (*Topic, error) {
-	
+
 	req, err := http.NewRequestWithContext(
 		ctx, http.MethodGet, s.cfg.BaseURL+"/topics/"+topicID, http.NoBody)
 	if err != nil {
@@ -62,7 +141,7 @@ func (s *streamkapAPI) GetTopic(ctx context.Context, topicID string) (*Topic, er
 		req.Method,
 		req.URL.String(),
 	))
-	
+
 	var resp Topic
 	err = s.doRequest(ctx, req, &resp)
 	if err != nil {
@@ -1,26 +1,119 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/constants"
 )
 
-type GetTransformResponse struct {
-	Total    int         `json:"total"`
-	PageSize int         `json:"page_size"`
-	Page     int         `json:"page"`
-	Result   []Transform `json:"result"`
-}
+type GetTransformResponse = Page[Transform]
 
 type Transform struct {
-	ID        string   `json:"id,omitempty"`
-	Name      string   `json:"name"`
-	StartTime *string  `json:"start_time"`
-	TopicIDs  []string `json:"topic_ids"`
-	Topics    []string `json:"topics"`
+	ID                 string   `json:"id,omitempty"`
+	Name               string   `json:"name"`
+	TransformType      string   `json:"transform_type,omitempty"`
+	Language           string   `json:"language,omitempty"`
+	Code               string   `json:"code,omitempty"`
+	InputTopicPattern  string   `json:"input_topic_pattern,omitempty"`
+	OutputTopicPattern string   `json:"output_topic_pattern,omitempty"`
+	StartTime          *string  `json:"start_time"`
+	TopicIDs           []string `json:"topic_ids,omitempty"`
+	Topics             []string `json:"topics,omitempty"`
+}
+
+func (s *streamkapAPI) CreateTransform(ctx context.Context, reqPayload Transform) (*Transform, error) {
+	payload, err := json.Marshal(reqPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var payloadMap map[string]any
+	err = json.Unmarshal(payload, &payloadMap)
+	if err != nil {
+		return nil, err
+	}
+
+	payloadMap["created_from"] = constants.TERRAFORM
+
+	payload, err = json.Marshal(payloadMap)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.BaseURL+"/transforms?unwind_topics=false", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"CreateTransform request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n"+
+			"\tBody: %s",
+		req.Method,
+		req.URL.String(),
+		redactBody(payload),
+	))
+	var resp Transform
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *streamkapAPI) UpdateTransform(ctx context.Context, transformID string, reqPayload Transform) (*Transform, error) {
+	payload, err := json.Marshal(reqPayload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, s.cfg.BaseURL+"/transforms/"+transformID+"?unwind_topics=false", bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"UpdateTransform request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n"+
+			"\tBody: %s",
+		req.Method,
+		req.URL.String(),
+		redactBody(payload),
+	))
+	var resp Transform
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *streamkapAPI) DeleteTransform(ctx context.Context, transformID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.cfg.BaseURL+"/transforms/"+transformID, http.NoBody)
+	if err != nil {
+		return err
+	}
+	tflog.Debug(ctx, fmt.Sprintf(
+		"DeleteTransform request details:\n"+
+			"\tMethod: %s\n"+
+			"\tURL: %s\n",
+		req.Method,
+		req.URL.String(),
+	))
+	var resp Transform
+	err = s.doRequest(ctx, req, &resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (s *streamkapAPI) GetTransform(ctx context.Context, TransformID string) (*Transform, error) {
@@ -42,7 +135,7 @@ func (s *streamkapAPI) GetTransform(ctx context.Context, TransformID string) (*T
 	}
 
 	if len(resp.Result) == 0 {
-		return nil, nil
+		return nil, notFound("transform", TransformID)
 	}
 
 	return &resp.Result[0], nil
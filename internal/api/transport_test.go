@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testCA is a self-signed certificate authority issuing test certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Streamkap Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+}
+
+// issue returns a PEM-encoded certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, usage x509.ExtKeyUsage) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func newTLSTestServer(t *testing.T, ca *testCA, clientCAs *x509.CertPool) *httptest.Server {
+	t.Helper()
+
+	certPEM, keyPEM := ca.issue(t, x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{{ID: "src"}}})
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	if clientCAs != nil {
+		server.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		server.TLS.ClientCAs = clientCAs
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func getSourceWith(t *testing.T, cfg *Config) error {
+	t.Helper()
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	_, err = client.GetSource(context.Background(), "src")
+	return err
+}
+
+func TestTransportCustomCA(t *testing.T) {
+	ca := newTestCA(t)
+	server := newTLSTestServer(t, ca, nil)
+
+	if err := getSourceWith(t, &Config{BaseURL: server.URL}); err == nil {
+		t.Error("expected the self-signed CA to be rejected by default")
+	}
+
+	if err := getSourceWith(t, &Config{BaseURL: server.URL, CACertPEM: ca.pem}); err != nil {
+		t.Errorf("expected ca_cert_pem to be trusted, got %s", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(ca.pem), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := getSourceWith(t, &Config{BaseURL: server.URL, CACertFile: caFile}); err != nil {
+		t.Errorf("expected ca_cert_file to be trusted, got %s", err)
+	}
+
+	if err := getSourceWith(t, &Config{BaseURL: server.URL, InsecureSkipVerify: true}); err != nil {
+		t.Errorf("expected insecure_skip_verify to skip verification, got %s", err)
+	}
+}
+
+func TestTransportMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server := newTLSTestServer(t, ca, clientCAs)
+
+	if err := getSourceWith(t, &Config{BaseURL: server.URL, CACertPEM: ca.pem}); err == nil {
+		t.Error("expected the server to require a client certificate")
+	}
+
+	clientCert, clientKey := ca.issue(t, x509.ExtKeyUsageClientAuth)
+	err := getSourceWith(t, &Config{
+		BaseURL:    server.URL,
+		CACertPEM:  ca.pem,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+	})
+	if err != nil {
+		t.Errorf("expected mutual TLS to succeed, got %s", err)
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute URL of the target.
+		if r.URL.Host == "api.streamkap.test" {
+			proxied.Add(1)
+		}
+		json.NewEncoder(w).Encode(GetSourceResponse{Result: []Source{{ID: "src"}}})
+	}))
+	t.Cleanup(proxy.Close)
+
+	if err := getSourceWith(t, &Config{BaseURL: "http://api.streamkap.test", HTTPProxy: proxy.URL}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if proxied.Load() != 1 {
+		t.Error("expected the request to go through the proxy")
+	}
+}
+
+func TestTransportRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	err := getSourceWith(t, &Config{BaseURL: server.URL, RequestTimeout: 20 * time.Millisecond})
+	if err == nil || !isTimeout(err) {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
+
+func TestTransportInvalidConfig(t *testing.T) {
+	ca := newTestCA(t)
+	clientCert, _ := ca.issue(t, x509.ExtKeyUsageClientAuth)
+
+	cases := map[string]*Config{
+		"bad CA":      {CACertPEM: "not a certificate"},
+		"missing CA":  {CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"lonely cert": {ClientCert: clientCert},
+		"bad proxy":   {HTTPProxy: "proxy.example.com"},
+	}
+
+	for name, cfg := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient(cfg); err == nil || strings.TrimSpace(err.Error()) == "" {
+				t.Errorf("expected an error, got %v", err)
+			}
+		})
+	}
+}
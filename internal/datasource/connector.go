@@ -0,0 +1,65 @@
+package datasource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// lookupByName returns the only ID in ids, the objects of the given kind
+// named name, or an error when there is none or several.
+func lookupByName(kind, name string, ids []string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	switch len(ids) {
+	case 1:
+		return ids[0], diags
+	case 0:
+		diags.AddAttributeError(
+			path.Root("name"),
+			fmt.Sprintf("Error reading %s", kind),
+			fmt.Sprintf("No %s is named %q", kind, name),
+		)
+	default:
+		diags.AddAttributeError(
+			path.Root("name"),
+			fmt.Sprintf("Error reading %s", kind),
+			fmt.Sprintf("%d %ss are named %q (%s), look it up by id instead", len(ids), kind, name, strings.Join(ids, ", ")),
+		)
+	}
+	return "", diags
+}
+
+// publicConfig returns the connector config without its secrets, with
+// non-string values encoded as JSON.
+func publicConfig(config map[string]any) map[string]types.String {
+	res := map[string]types.String{}
+	for key, value := range config {
+		if value == nil || api.IsSensitiveKey(key) {
+			continue
+		}
+		if s, ok := value.(string); ok {
+			res[key] = types.StringValue(s)
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		res[key] = types.StringValue(string(encoded))
+	}
+	return res
+}
+
+// topicName returns the name of a topic as used in pipelines, e.g.
+// public.users.
+func topicName(topic api.Topic) string {
+	if topic.Name != "" {
+		return topic.Name
+	}
+	return topic.TopicID
+}
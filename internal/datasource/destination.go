@@ -0,0 +1,190 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ds.DataSource = &DestinationDataSource{}
+
+func NewDestinationDataSource() ds.DataSource {
+	return &DestinationDataSource{}
+}
+
+// DestinationDataSource defines the data source implementation.
+type DestinationDataSource struct {
+	client api.StreamkapAPI
+}
+
+// DestinationDataSourceModel describes the data source data model.
+type DestinationDataSourceModel struct {
+	ID        types.String            `tfsdk:"id"`
+	Name      types.String            `tfsdk:"name"`
+	Connector types.String            `tfsdk:"connector"`
+	Config    map[string]types.String `tfsdk:"config"`
+	Topics    []types.String          `tfsdk:"topics"`
+}
+
+func (d *DestinationDataSource) Metadata(ctx context.Context, req ds.MetadataRequest, resp *ds.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destination"
+}
+
+func (d *DestinationDataSource) Schema(ctx context.Context, req ds.SchemaRequest, resp *ds.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Destination data source, looks up an existing destination by `id` or exact `name`, e.g. to reference it from a pipeline",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Destination identifier. Exactly one of id or name must be set.",
+				MarkdownDescription: "Destination identifier. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Destination name",
+				MarkdownDescription: "Destination name",
+				Optional:            true,
+				Computed:            true,
+			},
+			"connector": schema.StringAttribute{
+				Description:         "Destination connector",
+				MarkdownDescription: "Destination connector",
+				Computed:            true,
+			},
+			"config": schema.MapAttribute{
+				Description:         "Destination config, without the secrets",
+				MarkdownDescription: "Destination config, without the secrets",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"topics": schema.ListAttribute{
+				Description:         "List of the topics delivered to the destination by its pipelines",
+				MarkdownDescription: "List of the topics delivered to the destination by its pipelines",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *DestinationDataSource) Configure(ctx context.Context, req ds.ConfigureRequest, resp *ds.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Destination Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DestinationDataSource) Read(ctx context.Context, req ds.ReadRequest, resp *ds.ReadResponse) {
+	var state DestinationDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destinationID := state.ID.ValueString()
+	if state.ID.IsNull() {
+		destinations, err := d.client.ListDestinations(ctx, api.ListOptions{Name: state.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading destination",
+				fmt.Sprintf("Unable to list destinations, got error: %s", err),
+			)
+			return
+		}
+		ids := []string{}
+		for _, destination := range destinations {
+			if destination.Name == state.Name.ValueString() {
+				ids = append(ids, destination.ID)
+			}
+		}
+		var diags diag.Diagnostics
+		destinationID, diags = lookupByName("destination", state.Name.ValueString(), ids)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	destination, err := d.client.GetDestination(ctx, destinationID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error reading destination",
+			fmt.Sprintf("Destination %s does not exist", destinationID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading destination",
+			fmt.Sprintf("Unable to read destination, got error: %s", err),
+		)
+		return
+	}
+
+	// The destination receives the topics its pipelines select.
+	pipelines, err := d.client.ListPipelines(ctx, api.ListOptions{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading destination",
+			fmt.Sprintf("Unable to list the pipelines of destination %s, got error: %s", destinationID, err),
+		)
+		return
+	}
+	topics := []string{}
+	seen := map[string]bool{}
+	for _, pipeline := range pipelines {
+		if pipeline.Destination.ID != destinationID {
+			continue
+		}
+		for _, topic := range pipeline.Source.Topics {
+			if !seen[topic] {
+				seen[topic] = true
+				topics = append(topics, topic)
+			}
+		}
+	}
+
+	state.ID = types.StringValue(destination.ID)
+	state.Name = types.StringValue(destination.Name)
+	state.Connector = types.StringValue(destination.Connector)
+	state.Config = publicConfig(destination.Config)
+	state.Topics = []types.String{}
+	for _, topic := range topics {
+		state.Topics = append(state.Topics, types.StringValue(topic))
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,116 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ds.DataSource = &DestinationsDataSource{}
+
+func NewDestinationsDataSource() ds.DataSource {
+	return &DestinationsDataSource{}
+}
+
+// DestinationsDataSource defines the data source implementation.
+type DestinationsDataSource struct {
+	client api.StreamkapAPI
+}
+
+// DestinationsDataSourceModel describes the data source data model.
+type DestinationsDataSourceModel struct {
+	Connector    types.String           `tfsdk:"connector"`
+	NameRegex    types.String           `tfsdk:"name_regex"`
+	TagID        types.String           `tfsdk:"tag_id"`
+	Destinations []ConnectorObjectModel `tfsdk:"destinations"`
+}
+
+func (d *DestinationsDataSource) Metadata(ctx context.Context, req ds.MetadataRequest, resp *ds.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destinations"
+}
+
+func (d *DestinationsDataSource) Schema(ctx context.Context, req ds.SchemaRequest, resp *ds.SchemaResponse) {
+	attributes := filterAttributes("destinations")
+	attributes["destinations"] = schema.ListNestedAttribute{
+		Description:         "List of the matching destinations",
+		MarkdownDescription: "List of the matching destinations",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: connectorObjectAttributes("Destination"),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Destinations data source, lists the destinations matching the filters",
+		Attributes:          attributes,
+	}
+}
+
+func (d *DestinationsDataSource) Configure(ctx context.Context, req ds.ConfigureRequest, resp *ds.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Destinations Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DestinationsDataSource) Read(ctx context.Context, req ds.ReadRequest, resp *ds.ReadResponse) {
+	var state DestinationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRe, diags := nameFilter(state.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destinations, err := d.client.ListDestinations(ctx, listOptions(state.Connector, state.TagID))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading destinations",
+			fmt.Sprintf("Unable to list destinations, got error: %s", err),
+		)
+		return
+	}
+
+	state.Destinations = []ConnectorObjectModel{}
+	for _, destination := range destinations {
+		if nameRe != nil && !nameRe.MatchString(destination.Name) {
+			continue
+		}
+		state.Destinations = append(state.Destinations, ConnectorObjectModel{
+			ID:        types.StringValue(destination.ID),
+			Name:      types.StringValue(destination.Name),
+			Connector: types.StringValue(destination.Connector),
+			Tags:      stringValues(destination.Tags),
+		})
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,103 @@
+package datasource
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// ConnectorObjectModel is an element of the streamkap_sources and
+// streamkap_destinations lists.
+type ConnectorObjectModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	Connector types.String   `tfsdk:"connector"`
+	Tags      []types.String `tfsdk:"tags"`
+}
+
+// filterAttributes returns the filter attributes shared by the list data
+// sources; objects is the plural name used in the descriptions.
+func filterAttributes(objects string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"connector": schema.StringAttribute{
+			Description:         fmt.Sprintf("Only return %s of this connector, e.g. postgresql", objects),
+			MarkdownDescription: fmt.Sprintf("Only return %s of this connector, e.g. `postgresql`", objects),
+			Optional:            true,
+		},
+		"name_regex": schema.StringAttribute{
+			Description:         fmt.Sprintf("Only return %s whose name matches this regular expression", objects),
+			MarkdownDescription: fmt.Sprintf("Only return %s whose name matches this regular expression", objects),
+			Optional:            true,
+		},
+		"tag_id": schema.StringAttribute{
+			Description:         fmt.Sprintf("Only return %s carrying this tag", objects),
+			MarkdownDescription: fmt.Sprintf("Only return %s carrying this tag", objects),
+			Optional:            true,
+		},
+	}
+}
+
+// connectorObjectAttributes describes a ConnectorObjectModel.
+func connectorObjectAttributes(object string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description:         fmt.Sprintf("%s identifier", object),
+			MarkdownDescription: fmt.Sprintf("%s identifier", object),
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			Description:         fmt.Sprintf("%s name", object),
+			MarkdownDescription: fmt.Sprintf("%s name", object),
+			Computed:            true,
+		},
+		"connector": schema.StringAttribute{
+			Description:         fmt.Sprintf("%s connector", object),
+			MarkdownDescription: fmt.Sprintf("%s connector", object),
+			Computed:            true,
+		},
+		"tags": schema.ListAttribute{
+			Description:         "List of tag identifiers",
+			MarkdownDescription: "List of tag identifiers",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+	}
+}
+
+// nameFilter compiles the name_regex filter. A nil regexp matches every
+// name.
+func nameFilter(nameRegex types.String) (*regexp.Regexp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if nameRegex.IsNull() || nameRegex.ValueString() == "" {
+		return nil, diags
+	}
+	re, err := regexp.Compile(nameRegex.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("name_regex"),
+			"Invalid name_regex",
+			fmt.Sprintf("Unable to compile %q, got error: %s", nameRegex.ValueString(), err),
+		)
+	}
+	return re, diags
+}
+
+func listOptions(connector, tagID types.String) api.ListOptions {
+	return api.ListOptions{
+		Connector: connector.ValueString(),
+		TagID:     tagID.ValueString(),
+	}
+}
+
+func stringValues(values []string) []types.String {
+	res := []types.String{}
+	for _, v := range values {
+		res = append(res, types.StringValue(v))
+	}
+	return res
+}
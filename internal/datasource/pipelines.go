@@ -0,0 +1,177 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ds.DataSource = &PipelinesDataSource{}
+
+func NewPipelinesDataSource() ds.DataSource {
+	return &PipelinesDataSource{}
+}
+
+// PipelinesDataSource defines the data source implementation.
+type PipelinesDataSource struct {
+	client api.StreamkapAPI
+}
+
+// PipelinesDataSourceModel describes the data source data model.
+type PipelinesDataSourceModel struct {
+	Connector types.String          `tfsdk:"connector"`
+	NameRegex types.String          `tfsdk:"name_regex"`
+	TagID     types.String          `tfsdk:"tag_id"`
+	Pipelines []PipelineObjectModel `tfsdk:"pipelines"`
+}
+
+type PipelineObjectModel struct {
+	ID                   types.String   `tfsdk:"id"`
+	Name                 types.String   `tfsdk:"name"`
+	SourceID             types.String   `tfsdk:"source_id"`
+	SourceConnector      types.String   `tfsdk:"source_connector"`
+	DestinationID        types.String   `tfsdk:"destination_id"`
+	DestinationConnector types.String   `tfsdk:"destination_connector"`
+	Tags                 []types.String `tfsdk:"tags"`
+}
+
+func (d *PipelinesDataSource) Metadata(ctx context.Context, req ds.MetadataRequest, resp *ds.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipelines"
+}
+
+func (d *PipelinesDataSource) Schema(ctx context.Context, req ds.SchemaRequest, resp *ds.SchemaResponse) {
+	attributes := filterAttributes("pipelines")
+	attributes["connector"] = schema.StringAttribute{
+		Description:         "Only return pipelines whose source or destination uses this connector, e.g. postgresql",
+		MarkdownDescription: "Only return pipelines whose source or destination uses this connector, e.g. `postgresql`",
+		Optional:            true,
+	}
+	attributes["pipelines"] = schema.ListNestedAttribute{
+		Description:         "List of the matching pipelines",
+		MarkdownDescription: "List of the matching pipelines",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Description:         "Pipeline identifier",
+					MarkdownDescription: "Pipeline identifier",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					Description:         "Pipeline name",
+					MarkdownDescription: "Pipeline name",
+					Computed:            true,
+				},
+				"source_id": schema.StringAttribute{
+					Description:         "Source identifier",
+					MarkdownDescription: "Source identifier",
+					Computed:            true,
+				},
+				"source_connector": schema.StringAttribute{
+					Description:         "Source connector",
+					MarkdownDescription: "Source connector",
+					Computed:            true,
+				},
+				"destination_id": schema.StringAttribute{
+					Description:         "Destination identifier",
+					MarkdownDescription: "Destination identifier",
+					Computed:            true,
+				},
+				"destination_connector": schema.StringAttribute{
+					Description:         "Destination connector",
+					MarkdownDescription: "Destination connector",
+					Computed:            true,
+				},
+				"tags": schema.ListAttribute{
+					Description:         "List of tag identifiers",
+					MarkdownDescription: "List of tag identifiers",
+					Computed:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Pipelines data source, lists the pipelines matching the filters",
+		Attributes:          attributes,
+	}
+}
+
+func (d *PipelinesDataSource) Configure(ctx context.Context, req ds.ConfigureRequest, resp *ds.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Pipelines Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PipelinesDataSource) Read(ctx context.Context, req ds.ReadRequest, resp *ds.ReadResponse) {
+	var state PipelinesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRe, diags := nameFilter(state.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A pipeline has two connectors, the connector filter is applied here
+	// rather than by the API.
+	pipelines, err := d.client.ListPipelines(ctx, api.ListOptions{TagID: state.TagID.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading pipelines",
+			fmt.Sprintf("Unable to list pipelines, got error: %s", err),
+		)
+		return
+	}
+
+	connector := state.Connector.ValueString()
+	state.Pipelines = []PipelineObjectModel{}
+	for _, pipeline := range pipelines {
+		if nameRe != nil && !nameRe.MatchString(pipeline.Name) {
+			continue
+		}
+		if connector != "" && pipeline.Source.Connector != connector && pipeline.Destination.Connector != connector {
+			continue
+		}
+		state.Pipelines = append(state.Pipelines, PipelineObjectModel{
+			ID:                   types.StringValue(pipeline.ID),
+			Name:                 types.StringValue(pipeline.Name),
+			SourceID:             types.StringValue(pipeline.Source.ID),
+			SourceConnector:      types.StringValue(pipeline.Source.Connector),
+			DestinationID:        types.StringValue(pipeline.Destination.ID),
+			DestinationConnector: types.StringValue(pipeline.Destination.Connector),
+			Tags:                 stringValues(pipeline.Tags),
+		})
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,176 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ds.DataSource = &SourceDataSource{}
+
+func NewSourceDataSource() ds.DataSource {
+	return &SourceDataSource{}
+}
+
+// SourceDataSource defines the data source implementation.
+type SourceDataSource struct {
+	client api.StreamkapAPI
+}
+
+// SourceDataSourceModel describes the data source data model.
+type SourceDataSourceModel struct {
+	ID        types.String            `tfsdk:"id"`
+	Name      types.String            `tfsdk:"name"`
+	Connector types.String            `tfsdk:"connector"`
+	Config    map[string]types.String `tfsdk:"config"`
+	Topics    []types.String          `tfsdk:"topics"`
+}
+
+func (d *SourceDataSource) Metadata(ctx context.Context, req ds.MetadataRequest, resp *ds.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source"
+}
+
+func (d *SourceDataSource) Schema(ctx context.Context, req ds.SchemaRequest, resp *ds.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Source data source, looks up an existing source by `id` or exact `name`, e.g. to reference it from a pipeline",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Source identifier. Exactly one of id or name must be set.",
+				MarkdownDescription: "Source identifier. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Source name",
+				MarkdownDescription: "Source name",
+				Optional:            true,
+				Computed:            true,
+			},
+			"connector": schema.StringAttribute{
+				Description:         "Source connector",
+				MarkdownDescription: "Source connector",
+				Computed:            true,
+			},
+			"config": schema.MapAttribute{
+				Description:         "Source config, without the secrets",
+				MarkdownDescription: "Source config, without the secrets",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"topics": schema.ListAttribute{
+				Description:         "List of the topics produced by the source",
+				MarkdownDescription: "List of the topics produced by the source",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *SourceDataSource) Configure(ctx context.Context, req ds.ConfigureRequest, resp *ds.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Source Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SourceDataSource) Read(ctx context.Context, req ds.ReadRequest, resp *ds.ReadResponse) {
+	var state SourceDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceID := state.ID.ValueString()
+	if state.ID.IsNull() {
+		sources, err := d.client.ListSources(ctx, api.ListOptions{Name: state.Name.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading source",
+				fmt.Sprintf("Unable to list sources, got error: %s", err),
+			)
+			return
+		}
+		ids := []string{}
+		for _, source := range sources {
+			if source.Name == state.Name.ValueString() {
+				ids = append(ids, source.ID)
+			}
+		}
+		var diags diag.Diagnostics
+		sourceID, diags = lookupByName("source", state.Name.ValueString(), ids)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	source, err := d.client.GetSource(ctx, sourceID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error reading source",
+			fmt.Sprintf("Source %s does not exist", sourceID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading source",
+			fmt.Sprintf("Unable to read source, got error: %s", err),
+		)
+		return
+	}
+
+	topics, err := d.client.ListTopics(ctx, api.ListOptions{SourceID: sourceID})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading source",
+			fmt.Sprintf("Unable to list the topics of source %s, got error: %s", sourceID, err),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(source.ID)
+	state.Name = types.StringValue(source.Name)
+	state.Connector = types.StringValue(source.Connector)
+	state.Config = publicConfig(source.Config)
+	state.Topics = []types.String{}
+	for _, topic := range topics {
+		state.Topics = append(state.Topics, types.StringValue(topicName(topic)))
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
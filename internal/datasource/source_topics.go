@@ -0,0 +1,167 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ds.DataSource = &SourceTopicsDataSource{}
+
+func NewSourceTopicsDataSource() ds.DataSource {
+	return &SourceTopicsDataSource{}
+}
+
+// SourceTopicsDataSource defines the data source implementation.
+type SourceTopicsDataSource struct {
+	client api.StreamkapAPI
+}
+
+// SourceTopicsDataSourceModel describes the data source data model.
+type SourceTopicsDataSourceModel struct {
+	SourceID  types.String   `tfsdk:"source_id"`
+	NameRegex types.String   `tfsdk:"name_regex"`
+	Topics    []types.String `tfsdk:"topics"`
+	TopicIDs  []types.String `tfsdk:"topic_ids"`
+	TopicMap  []TopicModel   `tfsdk:"topic_map"`
+}
+
+func (d *SourceTopicsDataSource) Metadata(ctx context.Context, req ds.MetadataRequest, resp *ds.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_topics"
+}
+
+func (d *SourceTopicsDataSource) Schema(ctx context.Context, req ds.SchemaRequest, resp *ds.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Source topics data source, lists the topics a source has produced, e.g. to fill `source.topics` of a `streamkap_pipeline`",
+
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Description:         "Source identifier",
+				MarkdownDescription: "Source identifier",
+				Required:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description:         "Only return topics whose name matches this regular expression",
+				MarkdownDescription: "Only return topics whose name matches this regular expression",
+				Optional:            true,
+			},
+			"topics": schema.ListAttribute{
+				Description:         "List of topic names, as used in pipelines",
+				MarkdownDescription: "List of topic names, as used in pipelines",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"topic_ids": schema.ListAttribute{
+				Description:         "List of topic identifiers",
+				MarkdownDescription: "List of topic identifiers",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"topic_map": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "List of topic object, with id and name for each topic",
+				MarkdownDescription: "List of topic object, with id and name for each topic",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SourceTopicsDataSource) Configure(ctx context.Context, req ds.ConfigureRequest, resp *ds.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Source Topics Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SourceTopicsDataSource) Read(ctx context.Context, req ds.ReadRequest, resp *ds.ReadResponse) {
+	var state SourceTopicsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRe, diags := nameFilter(state.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The topics endpoint returns nothing for an unknown source, check it
+	// exists to fail loudly on a wrong ID.
+	sourceID := state.SourceID.ValueString()
+	if _, err := d.client.GetSource(ctx, sourceID); errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error reading source topics",
+			fmt.Sprintf("Source %s does not exist", sourceID),
+		)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading source topics",
+			fmt.Sprintf("Unable to read source, got error: %s", err),
+		)
+		return
+	}
+
+	topics, err := d.client.ListTopics(ctx, api.ListOptions{SourceID: sourceID})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading source topics",
+			fmt.Sprintf("Unable to list the topics of source %s, got error: %s", sourceID, err),
+		)
+		return
+	}
+
+	state.Topics = []types.String{}
+	state.TopicIDs = []types.String{}
+	state.TopicMap = []TopicModel{}
+	for _, topic := range topics {
+		name := topicName(topic)
+		if nameRe != nil && !nameRe.MatchString(name) {
+			continue
+		}
+		state.Topics = append(state.Topics, types.StringValue(name))
+		state.TopicIDs = append(state.TopicIDs, types.StringValue(topic.TopicID))
+		state.TopicMap = append(state.TopicMap, TopicModel{
+			ID:   types.StringValue(topic.TopicID),
+			Name: types.StringValue(name),
+		})
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
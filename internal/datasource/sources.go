@@ -0,0 +1,116 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+
+	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ds.DataSource = &SourcesDataSource{}
+
+func NewSourcesDataSource() ds.DataSource {
+	return &SourcesDataSource{}
+}
+
+// SourcesDataSource defines the data source implementation.
+type SourcesDataSource struct {
+	client api.StreamkapAPI
+}
+
+// SourcesDataSourceModel describes the data source data model.
+type SourcesDataSourceModel struct {
+	Connector types.String           `tfsdk:"connector"`
+	NameRegex types.String           `tfsdk:"name_regex"`
+	TagID     types.String           `tfsdk:"tag_id"`
+	Sources   []ConnectorObjectModel `tfsdk:"sources"`
+}
+
+func (d *SourcesDataSource) Metadata(ctx context.Context, req ds.MetadataRequest, resp *ds.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sources"
+}
+
+func (d *SourcesDataSource) Schema(ctx context.Context, req ds.SchemaRequest, resp *ds.SchemaResponse) {
+	attributes := filterAttributes("sources")
+	attributes["sources"] = schema.ListNestedAttribute{
+		Description:         "List of the matching sources",
+		MarkdownDescription: "List of the matching sources",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: connectorObjectAttributes("Source"),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Sources data source, lists the sources matching the filters",
+		Attributes:          attributes,
+	}
+}
+
+func (d *SourcesDataSource) Configure(ctx context.Context, req ds.ConfigureRequest, resp *ds.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Sources Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SourcesDataSource) Read(ctx context.Context, req ds.ReadRequest, resp *ds.ReadResponse) {
+	var state SourcesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRe, diags := nameFilter(state.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sources, err := d.client.ListSources(ctx, listOptions(state.Connector, state.TagID))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading sources",
+			fmt.Sprintf("Unable to list sources, got error: %s", err),
+		)
+		return
+	}
+
+	state.Sources = []ConnectorObjectModel{}
+	for _, source := range sources {
+		if nameRe != nil && !nameRe.MatchString(source.Name) {
+			continue
+		}
+		state.Sources = append(state.Sources, ConnectorObjectModel{
+			ID:        types.StringValue(source.ID),
+			Name:      types.StringValue(source.Name),
+			Connector: types.StringValue(source.Connector),
+			Tags:      stringValues(source.Tags),
+		})
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
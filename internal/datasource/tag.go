@@ -2,6 +2,7 @@ package datasource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -43,8 +44,8 @@ func (d *TagDataSource) Schema(ctx context.Context, req ds.SchemaRequest, resp *
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description:         "Tag identifier. Currently, we only have 2 tags: `Development` tag with ID `670e5ca40afe1d3983ce0c22` and `Production` tag with ID `670e5bab0d119c0d1f8cda9d`",
-				MarkdownDescription: "Tag identifier. Currently, we only have 2 tags: `Development` tag with ID `670e5ca40afe1d3983ce0c22` and `Production` tag with ID `670e5bab0d119c0d1f8cda9d`",
+				Description:         "Tag identifier. The system tags are `Development` with ID `670e5ca40afe1d3983ce0c22` and `Production` with ID `670e5bab0d119c0d1f8cda9d`; custom tags can be managed with the `streamkap_tag` resource",
+				MarkdownDescription: "Tag identifier. The system tags are `Development` with ID `670e5ca40afe1d3983ce0c22` and `Production` with ID `670e5bab0d119c0d1f8cda9d`; custom tags can be managed with the `streamkap_tag` resource",
 				Required:            true,
 			},
 			"name": schema.StringAttribute{
@@ -107,17 +108,17 @@ func (d *TagDataSource) Read(ctx context.Context, req ds.ReadRequest, resp *ds.R
 
 	TagID := state.ID.ValueString()
 	Tag, err := d.client.GetTag(ctx, TagID)
-	if err != nil {
+	if errors.Is(err, api.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error reading Tag",
-			fmt.Sprintf("Unable to read Tag, got error: %s", err),
+			fmt.Sprintf("Tag %s does not exist", TagID),
 		)
 		return
 	}
-	if Tag == nil {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Tag",
-			fmt.Sprintf("Tag %s does not exist", TagID),
+			fmt.Sprintf("Unable to read Tag, got error: %s", err),
 		)
 		return
 	}
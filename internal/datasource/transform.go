@@ -2,6 +2,7 @@ package datasource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	ds "github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -116,17 +117,17 @@ func (d *TransformDataSource) Read(ctx context.Context, req ds.ReadRequest, resp
 
 	transformID := state.ID.ValueString()
 	transform, err := d.client.GetTransform(ctx, transformID)
-	if err != nil {
+	if errors.Is(err, api.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error reading transform",
-			fmt.Sprintf("Unable to read transform, got error: %s", err),
+			fmt.Sprintf("transform %s does not exist", transformID),
 		)
 		return
 	}
-	if transform == nil {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading transform",
-			fmt.Sprintf("transform %s does not exist", transformID),
+			fmt.Sprintf("Unable to read transform, got error: %s", err),
 		)
 		return
 	}
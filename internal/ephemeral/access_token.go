@@ -0,0 +1,142 @@
+package ephemeral
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eph "github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// ProviderData is handed by the provider to the ephemeral resources. Unlike
+// the resources, they need the client credentials to exchange them again.
+type ProviderData struct {
+	Client   api.StreamkapAPI
+	ClientID string
+	Secret   string
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ eph.EphemeralResource              = &AccessTokenEphemeralResource{}
+	_ eph.EphemeralResourceWithConfigure = &AccessTokenEphemeralResource{}
+)
+
+func NewAccessTokenEphemeralResource() eph.EphemeralResource {
+	return &AccessTokenEphemeralResource{}
+}
+
+// AccessTokenEphemeralResource defines the ephemeral resource implementation.
+// It exchanges the provider credentials for a new access token on every run,
+// which Terraform never saves in the plan or state.
+type AccessTokenEphemeralResource struct {
+	client   api.StreamkapAPI
+	clientID string
+	secret   string
+}
+
+// AccessTokenEphemeralResourceModel describes the ephemeral resource data model.
+type AccessTokenEphemeralResourceModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	ExpiresIn   types.Int64  `tfsdk:"expires_in"`
+}
+
+func (r *AccessTokenEphemeralResource) Metadata(ctx context.Context, req eph.MetadataRequest, resp *eph.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *AccessTokenEphemeralResource) Schema(ctx context.Context, req eph.SchemaRequest, resp *eph.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Short-lived Streamkap API access token, exchanged for the provider client_id and secret. " +
+			"It is never saved in the plan or state, e.g. to call the Streamkap API from another provider. " +
+			"Requires Terraform 1.10 or later.",
+		MarkdownDescription: "Short-lived Streamkap API access token, exchanged for the provider `client_id` and `secret`. " +
+			"It is never saved in the plan or state, e.g. to call the Streamkap API from another provider. " +
+			"Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				Description:         "Access token, to send as a Bearer token in the Authorization header",
+				MarkdownDescription: "Access token, to send as a `Bearer` token in the `Authorization` header",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				Description:         "When the access token expires, as an RFC 3339 timestamp. Null when the API does not say.",
+				MarkdownDescription: "When the access token expires, as an RFC 3339 timestamp. Null when the API does not say.",
+			},
+			"expires_in": schema.Int64Attribute{
+				Computed:            true,
+				Description:         "Lifetime of the access token in seconds. Null when the API does not say.",
+				MarkdownDescription: "Lifetime of the access token in seconds. Null when the API does not say.",
+			},
+		},
+	}
+}
+
+func (r *AccessTokenEphemeralResource) Configure(ctx context.Context, req eph.ConfigureRequest, resp *eph.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Access Token Configure Type",
+			fmt.Sprintf("Expected *ephemeral.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.Client
+	r.clientID = data.ClientID
+	r.secret = data.Secret
+}
+
+func (r *AccessTokenEphemeralResource) Open(ctx context.Context, req eph.OpenRequest, resp *eph.OpenResponse) {
+	var data AccessTokenEphemeralResourceModel
+
+	// Read Terraform config data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.clientID == "" || r.secret == "" {
+		resp.Diagnostics.AddError(
+			"Missing Streamkap API Credentials",
+			"streamkap_access_token exchanges the provider client_id and secret for a new access token, "+
+				"but the provider is configured with a pre-issued token. Configure client_id and secret, "+
+				"or credentials_file, instead.",
+		)
+		return
+	}
+
+	issued := time.Now()
+	token, err := r.client.GetAccessToken(ctx, r.clientID, r.secret)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error opening access token",
+			fmt.Sprintf("Unable to get a Streamkap API access token, got error: %s", err),
+		)
+		return
+	}
+
+	data.AccessToken = types.StringValue(token.AccessToken)
+	data.ExpiresAt = types.StringNull()
+	data.ExpiresIn = types.Int64Null()
+	if expiry := token.ExpiresAt(issued); !expiry.IsZero() {
+		data.ExpiresAt = types.StringValue(expiry.UTC().Format(time.RFC3339))
+		data.ExpiresIn = types.Int64Value(int64(expiry.Sub(issued).Seconds()))
+	}
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,889 @@
+// Package fakeapi is an in-memory fake of the Streamkap REST API. It lets the
+// acceptance tests run without Streamkap credentials: point STREAMKAP_HOST at
+// Server.URL and authenticate with ClientID and Secret.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// Credentials accepted by the token exchange.
+const (
+	ClientID = "fake-client-id"
+	Secret   = "fake-secret"
+)
+
+const defaultPageSize = 10
+
+// Server is a Streamkap API backed by maps. Objects created through the API
+// persist until they are deleted or the server is closed.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	nextID int
+	// tokens are the access and refresh tokens issued so far.
+	tokens        map[string]bool
+	refreshTokens map[string]bool
+
+	sources      map[string]*api.Source
+	destinations map[string]*api.Destination
+	pipelines    map[string]*api.Pipeline
+	statuses     map[string]*api.ConnectorStatus
+	snapshots    map[string]*api.SourceSnapshot
+	transforms   map[string]*api.Transform
+	tags         map[string]*api.Tag
+	topics       map[string]*api.Topic
+}
+
+// NewServer starts a fake Streamkap API. Close it once done.
+func NewServer() *Server {
+	s := &Server{
+		tokens:        map[string]bool{},
+		refreshTokens: map[string]bool{},
+		sources:       map[string]*api.Source{},
+		destinations:  map[string]*api.Destination{},
+		pipelines:     map[string]*api.Pipeline{},
+		statuses:      map[string]*api.ConnectorStatus{},
+		snapshots:     map[string]*api.SourceSnapshot{},
+		transforms:    map[string]*api.Transform{},
+		tags:          map[string]*api.Tag{},
+		topics:        map[string]*api.Topic{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// newID returns an ID shaped like the API's 24 hex digit object IDs.
+// Callers must hold s.mu.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("fa4e%020x", s.nextID)
+}
+
+// AddTransform stores a transform and returns its ID, generated when t.ID
+// is empty.
+func (s *Server) AddTransform(t api.Transform) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.ID == "" {
+		t.ID = s.newID()
+	}
+	s.transforms[t.ID] = &t
+	return t.ID
+}
+
+// AddTag stores a tag and returns its ID, generated when t.ID is empty.
+func (s *Server) AddTag(t api.Tag) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.ID == "" {
+		t.ID = s.newID()
+	}
+	s.tags[t.ID] = &t
+	return t.ID
+}
+
+// AddTopic stores a topic, as if a source had produced it.
+func (s *Server) AddTopic(t api.Topic) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.topics[t.TopicID] = &t
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	collection, id := segments[0], ""
+	if len(segments) > 1 {
+		id = strings.Join(segments[1:], "/")
+	}
+
+	if collection == "auth" {
+		s.serveAuth(w, r, id)
+		return
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !s.tokens[token] {
+		writeError(w, http.StatusUnauthorized, "Invalid or expired access token")
+		return
+	}
+
+	switch collection {
+	case "sources":
+		s.serveSources(w, r, id)
+	case "destinations":
+		s.serveDestinations(w, r, id)
+	case "pipelines":
+		s.servePipelines(w, r, id)
+	case "transforms":
+		s.serveTransforms(w, r, id)
+	case "tags":
+		s.serveTags(w, r, id)
+	case "topics":
+		s.serveTopics(w, r, id)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveAuth(w http.ResponseWriter, r *http.Request, action string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+
+	switch action {
+	case "access-token":
+		var req api.GetAccessTokenRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if req.ClientID != ClientID || req.Secret != Secret {
+			writeError(w, http.StatusUnauthorized, "Invalid client_id or secret")
+			return
+		}
+	case "refresh":
+		var req api.RefreshAccessTokenRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if !s.refreshTokens[req.RefreshToken] {
+			writeError(w, http.StatusUnauthorized, "Invalid refresh token")
+			return
+		}
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	s.nextID++
+	token := api.Token{
+		AccessToken:  fmt.Sprintf("fake-access-token-%d", s.nextID),
+		ExpiresIn:    3600,
+		RefreshToken: fmt.Sprintf("fake-refresh-token-%d", s.nextID),
+	}
+	s.tokens[token.AccessToken] = true
+	s.refreshTokens[token.RefreshToken] = true
+	writeJSON(w, http.StatusOK, token)
+}
+
+func (s *Server) serveSources(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		q := r.URL.Query()
+		writePage(w, r, filter(sorted(s.sources), func(src *api.Source) bool {
+			return matches(q.Get("connector"), src.Connector) && matches(q.Get("name"), src.Name) && hasTag(q.Get("tag_ids"), src.Tags)
+		}))
+	case id == "" && r.Method == http.MethodPost:
+		var src api.Source
+		if !decode(w, r, &src) || !validateConnector(w, src.Name, src.Connector) {
+			return
+		}
+		src.ID = s.newID()
+		s.sources[src.ID] = &src
+		s.statuses[src.ID] = &api.ConnectorStatus{State: api.ConnectorStatusStarting}
+		s.materializeTopics(&src)
+		created := src
+		created.Config = withoutSecrets(r, src.Config)
+		writeJSON(w, http.StatusOK, created)
+	case id != "":
+		id, action, _ := strings.Cut(id, "/")
+		src, ok := s.sources[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Source %s not found", id))
+			return
+		}
+		if collection, snapshotID, _ := strings.Cut(action, "/"); collection == "snapshots" {
+			s.serveSourceSnapshots(w, r, src, snapshotID)
+			return
+		} else if action == "status" {
+			s.serveStatus(w, r, id)
+			return
+		} else if action != "" {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			found := *src
+			found.Config = withoutSecrets(r, src.Config)
+			writeJSON(w, http.StatusOK, api.Page[api.Source]{Total: 1, PageSize: 1, Page: 1, Result: []api.Source{found}})
+		case http.MethodPut:
+			var update api.Source
+			if !decode(w, r, &update) || !validateConnector(w, update.Name, src.Connector) {
+				return
+			}
+			src.Name = update.Name
+			src.Config = update.Config
+			s.redeploy(id)
+			updated := *src
+			updated.Config = withoutSecrets(r, src.Config)
+			writeJSON(w, http.StatusOK, updated)
+		case http.MethodDelete:
+			for _, p := range s.pipelines {
+				if p.Source.ID == id {
+					writeError(w, http.StatusConflict, fmt.Sprintf("Source %s is used by pipeline %s", id, p.ID))
+					return
+				}
+			}
+			delete(s.sources, id)
+			delete(s.statuses, id)
+			writeJSON(w, http.StatusOK, src)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// withoutSecrets returns a copy of a source or destination config without
+// its secrets, unless the request asked for them with secret_returned=true.
+func withoutSecrets(r *http.Request, config map[string]any) map[string]any {
+	if r.URL.Query().Get("secret_returned") == "true" {
+		return config
+	}
+
+	hidden := map[string]any{}
+	for key, value := range config {
+		if !api.IsSensitiveKey(key) {
+			hidden[key] = value
+		}
+	}
+	return hidden
+}
+
+// serveSourceSnapshots triggers and reports incremental snapshots. A
+// snapshot moves from PENDING to RUNNING to COMPLETED one step per read, as
+// if the backend made progress between two polls.
+func (s *Server) serveSourceSnapshots(w http.ResponseWriter, r *http.Request, src *api.Source, snapshotID string) {
+	switch {
+	case snapshotID == "" && r.Method == http.MethodPost:
+		var snapshot api.SourceSnapshot
+		if !decode(w, r, &snapshot) {
+			return
+		}
+		if len(snapshot.Tables) == 0 {
+			writeError(w, http.StatusUnprocessableEntity, "At least one table is required")
+			return
+		}
+		if snapshot.Type == "" {
+			snapshot.Type = api.SnapshotTypeIncremental
+		}
+		snapshot.ID = s.newID()
+		snapshot.Status = api.SnapshotStatusPending
+		s.snapshots[src.ID+"/"+snapshot.ID] = &snapshot
+		writeJSON(w, http.StatusOK, snapshot)
+	case snapshotID != "" && r.Method == http.MethodGet:
+		snapshot, ok := s.snapshots[src.ID+"/"+snapshotID]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Snapshot %s not found", snapshotID))
+			return
+		}
+		current := *snapshot
+		switch snapshot.Status {
+		case api.SnapshotStatusPending:
+			snapshot.Status = api.SnapshotStatusRunning
+		case api.SnapshotStatusRunning:
+			snapshot.Status = api.SnapshotStatusCompleted
+		}
+		writeJSON(w, http.StatusOK, current)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) serveDestinations(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		q := r.URL.Query()
+		writePage(w, r, filter(sorted(s.destinations), func(dst *api.Destination) bool {
+			return matches(q.Get("connector"), dst.Connector) && matches(q.Get("name"), dst.Name) && hasTag(q.Get("tag_ids"), dst.Tags)
+		}))
+	case id == "" && r.Method == http.MethodPost:
+		var dst api.Destination
+		if !decode(w, r, &dst) || !validateConnector(w, dst.Name, dst.Connector) {
+			return
+		}
+		dst.ID = s.newID()
+		s.destinations[dst.ID] = &dst
+		s.statuses[dst.ID] = &api.ConnectorStatus{State: api.ConnectorStatusStarting}
+		created := dst
+		created.Config = withoutSecrets(r, dst.Config)
+		writeJSON(w, http.StatusOK, created)
+	case id != "":
+		id, action, _ := strings.Cut(id, "/")
+		dst, ok := s.destinations[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Destination %s not found", id))
+			return
+		}
+		if action == "status" {
+			s.serveStatus(w, r, id)
+			return
+		} else if action != "" {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			found := *dst
+			found.Config = withoutSecrets(r, dst.Config)
+			writeJSON(w, http.StatusOK, api.Page[api.Destination]{Total: 1, PageSize: 1, Page: 1, Result: []api.Destination{found}})
+		case http.MethodPut:
+			var update api.Destination
+			if !decode(w, r, &update) || !validateConnector(w, update.Name, dst.Connector) {
+				return
+			}
+			dst.Name = update.Name
+			dst.Config = update.Config
+			s.redeploy(id)
+			updated := *dst
+			updated.Config = withoutSecrets(r, dst.Config)
+			writeJSON(w, http.StatusOK, updated)
+		case http.MethodDelete:
+			for _, p := range s.pipelines {
+				if p.Destination.ID == id {
+					writeError(w, http.StatusConflict, fmt.Sprintf("Destination %s is used by pipeline %s", id, p.ID))
+					return
+				}
+			}
+			delete(s.destinations, id)
+			delete(s.statuses, id)
+			writeJSON(w, http.StatusOK, dst)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) servePipelines(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		q := r.URL.Query()
+		writePage(w, r, filter(sorted(s.pipelines), func(p *api.Pipeline) bool {
+			return matches(q.Get("name"), p.Name) && hasTag(q.Get("tag_ids"), p.Tags)
+		}))
+	case id == "" && r.Method == http.MethodPost:
+		var p api.Pipeline
+		if !decode(w, r, &p) || !s.validatePipeline(w, &p) {
+			return
+		}
+		p.ID = s.newID()
+		s.pipelines[p.ID] = &p
+		s.statuses[p.ID] = &api.ConnectorStatus{State: api.ConnectorStatusStarting}
+		writeJSON(w, http.StatusOK, p)
+	case id != "":
+		id, action, _ := strings.Cut(id, "/")
+		p, ok := s.pipelines[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Pipeline %s not found", id))
+			return
+		}
+		if action != "" {
+			s.servePipelineAction(w, r, id, action)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, api.Page[api.Pipeline]{Total: 1, PageSize: 1, Page: 1, Result: []api.Pipeline{*p}})
+		case http.MethodPut:
+			var update api.Pipeline
+			if !decode(w, r, &update) || !s.validatePipeline(w, &update) {
+				return
+			}
+			update.ID = id
+			s.pipelines[id] = &update
+			s.redeploy(id)
+			writeJSON(w, http.StatusOK, update)
+		case http.MethodDelete:
+			delete(s.pipelines, id)
+			delete(s.statuses, id)
+			writeJSON(w, http.StatusOK, p)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// servePipelineAction serves /pipelines/{id}/status and the pause, resume
+// and restart actions.
+func (s *Server) servePipelineAction(w http.ResponseWriter, r *http.Request, id, action string) {
+	if action == "status" {
+		s.serveStatus(w, r, id)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	status, ok := s.statuses[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Status of %s not found", id))
+		return
+	}
+	switch action {
+	case "pause":
+		status.State = api.ConnectorStatusPaused
+	case "resume", "restart":
+		status.State = api.ConnectorStatusRunning
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	status.Trace = ""
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveStatus serves the status of a source, destination or pipeline. A
+// starting connector is reported RUNNING from the next read on, as if it
+// was deployed between two polls.
+func (s *Server) serveStatus(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	status, ok := s.statuses[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Status of %s not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+	if status.State == api.ConnectorStatusStarting {
+		status.State = api.ConnectorStatusRunning
+	}
+}
+
+// redeploy restarts the connector of an updated object, unless it is paused
+// or failed.
+func (s *Server) redeploy(id string) {
+	if status, ok := s.statuses[id]; ok && status.State == api.ConnectorStatusRunning {
+		status.State = api.ConnectorStatusStarting
+	}
+}
+
+// SetStatus overrides the status of a source, destination or pipeline, e.g.
+// to simulate a failure.
+func (s *Server) SetStatus(id string, status api.ConnectorStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statuses[id] = &status
+}
+
+func (s *Server) validatePipeline(w http.ResponseWriter, p *api.Pipeline) bool {
+	if p.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Pipeline name is required")
+		return false
+	}
+	src, ok := s.sources[p.Source.ID]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Source %s not found", p.Source.ID))
+		return false
+	}
+	dst, ok := s.destinations[p.Destination.ID]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Destination %s not found", p.Destination.ID))
+		return false
+	}
+	for _, t := range p.Transforms {
+		if _, ok := s.transforms[t.ID]; !ok {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Transform %s not found", t.ID))
+			return false
+		}
+	}
+	for _, tagID := range p.Tags {
+		if _, ok := s.tags[tagID]; !ok {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Tag %s not found", tagID))
+			return false
+		}
+	}
+	// Like the API, echo the names and connectors of the endpoints.
+	p.Source.Name, p.Source.Connector = src.Name, src.Connector
+	p.Destination.Name, p.Destination.Connector = dst.Name, dst.Connector
+	return true
+}
+
+func (s *Server) serveTransforms(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		q := r.URL.Query()
+		writePage(w, r, filter(sorted(s.transforms), func(t *api.Transform) bool {
+			return matches(q.Get("name"), t.Name)
+		}))
+	case id == "" && r.Method == http.MethodPost:
+		var t api.Transform
+		if !decode(w, r, &t) || !validateTransform(w, t) {
+			return
+		}
+		t.ID = s.newID()
+		normalizeTransform(&t, nil)
+		s.transforms[t.ID] = &t
+		writeJSON(w, http.StatusOK, t)
+	case id != "":
+		t, ok := s.transforms[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Transform %s not found", id))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, api.Page[api.Transform]{Total: 1, PageSize: 1, Page: 1, Result: []api.Transform{*t}})
+		case http.MethodPut:
+			var update api.Transform
+			if !decode(w, r, &update) || !validateTransform(w, update) {
+				return
+			}
+			update.ID = id
+			update.TopicIDs = t.TopicIDs
+			update.Topics = t.Topics
+			normalizeTransform(&update, t)
+			s.transforms[id] = &update
+			writeJSON(w, http.StatusOK, update)
+		case http.MethodDelete:
+			for _, p := range s.pipelines {
+				for _, pt := range p.Transforms {
+					if pt.ID == id {
+						writeError(w, http.StatusConflict, fmt.Sprintf("Transform %s is used by pipeline %s", id, p.ID))
+						return
+					}
+				}
+			}
+			delete(s.transforms, id)
+			writeJSON(w, http.StatusOK, t)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// normalizeTransform trims the code, as the API does, and fills in a missing
+// start time with the one of previous, or now for a new transform.
+func normalizeTransform(t *api.Transform, previous *api.Transform) {
+	t.Code = strings.TrimSpace(t.Code)
+	if t.StartTime != nil {
+		return
+	}
+	if previous != nil {
+		t.StartTime = previous.StartTime
+		return
+	}
+	startTime := time.Now().UTC().Format(time.RFC3339)
+	t.StartTime = &startTime
+}
+
+func validateTransform(w http.ResponseWriter, t api.Transform) bool {
+	if t.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Name is required")
+		return false
+	}
+	if t.TransformType == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Transform type is required")
+		return false
+	}
+	if t.InputTopicPattern == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Input topic pattern is required")
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveTags(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			// Tags are not paginated.
+			q := r.URL.Query()
+			tags := []api.Tag{}
+			for _, t := range sorted(s.tags) {
+				if hasTag(q.Get("tag_ids"), []string{t.ID}) && matches(q.Get("name"), t.Name) {
+					tags = append(tags, *t)
+				}
+			}
+			writeJSON(w, http.StatusOK, api.GetTagResponse{Tags: tags})
+		case http.MethodPost:
+			var payload api.TagPayload
+			if !decode(w, r, &payload) || !validateTag(w, payload) {
+				return
+			}
+			custom := true
+			t := &api.Tag{
+				ID:          s.newID(),
+				Name:        payload.Name,
+				Description: payload.Description,
+				Type:        payload.Type,
+				Custom:      &custom,
+			}
+			s.tags[t.ID] = t
+			writeJSON(w, http.StatusOK, t)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		}
+		return
+	}
+
+	t, ok := s.tags[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Tag %s not found", id))
+		return
+	}
+	if t.System && (r.Method == http.MethodPut || r.Method == http.MethodDelete) {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("Tag %s is a system tag and cannot be modified", id))
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		var payload api.TagPayload
+		if !decode(w, r, &payload) || !validateTag(w, payload) {
+			return
+		}
+		t.Name = payload.Name
+		t.Description = payload.Description
+		t.Type = payload.Type
+		writeJSON(w, http.StatusOK, t)
+	case http.MethodDelete:
+		for _, p := range s.pipelines {
+			if hasTag(id, p.Tags) {
+				writeError(w, http.StatusConflict, fmt.Sprintf("Tag %s is used by pipeline %s", id, p.ID))
+				return
+			}
+		}
+		delete(s.tags, id)
+		writeJSON(w, http.StatusOK, t)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func validateTag(w http.ResponseWriter, payload api.TagPayload) bool {
+	if payload.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Name is required")
+		return false
+	}
+	if len(payload.Type) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "At least one tag type is required")
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveTopics(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+			writePage(w, r, filter(sorted(s.topics), func(t *api.Topic) bool {
+				return matches(q.Get("source_id"), t.SourceID) && matches(q.Get("name"), t.Name)
+			}))
+		case http.MethodPost:
+			var create struct {
+				Payload api.Topic `json:"payload"`
+			}
+			if !decode(w, r, &create) {
+				return
+			}
+			t := create.Payload
+			if t.TopicID == "" {
+				writeError(w, http.StatusUnprocessableEntity, "Topic ID is required")
+				return
+			}
+			if t.PartitionCount < 1 {
+				writeError(w, http.StatusUnprocessableEntity, "Partition count must be at least 1")
+				return
+			}
+			if _, ok := s.topics[t.TopicID]; ok {
+				writeError(w, http.StatusConflict, fmt.Sprintf("Topic %s already exists", t.TopicID))
+				return
+			}
+			if t.ReplicationFactor == 0 {
+				t.ReplicationFactor = 3
+			}
+			if t.Name == "" {
+				t.Name = t.TopicID
+			}
+			s.topics[t.TopicID] = &t
+			writeJSON(w, http.StatusOK, t)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		}
+		return
+	}
+
+	t, ok := s.topics[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Topic %s not found", id))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, t)
+	case http.MethodPut:
+		var update struct {
+			Payload struct {
+				PartitionCount int               `json:"partition_count"`
+				Config         map[string]string `json:"config"`
+			} `json:"payload"`
+		}
+		if !decode(w, r, &update) {
+			return
+		}
+		if update.Payload.PartitionCount < t.PartitionCount {
+			writeError(w, http.StatusUnprocessableEntity,
+				fmt.Sprintf("The partition count of topic %s cannot be decreased from %d to %d", id, t.PartitionCount, update.Payload.PartitionCount))
+			return
+		}
+		t.PartitionCount = update.Payload.PartitionCount
+		for k, v := range update.Payload.Config {
+			if t.Config == nil {
+				t.Config = map[string]string{}
+			}
+			t.Config[k] = v
+		}
+		writeJSON(w, http.StatusOK, t)
+	case http.MethodDelete:
+		delete(s.topics, id)
+		writeJSON(w, http.StatusOK, t)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// materializeTopics creates the topics of the tables or collections a
+// source includes, as the backend does once the source has started.
+func (s *Server) materializeTopics(src *api.Source) {
+	for _, key := range []string{"table.include.list.user.defined", "collection.include.list.user.defined"} {
+		list, _ := src.Config[key].(string)
+		for _, table := range strings.Split(list, ",") {
+			table = strings.TrimSpace(table)
+			if table == "" {
+				continue
+			}
+			topicID := "source_" + src.ID + "." + table
+			s.topics[topicID] = &api.Topic{TopicID: topicID, Name: table, SourceID: src.ID, PartitionCount: 1}
+		}
+	}
+}
+
+func validateConnector(w http.ResponseWriter, name, connector string) bool {
+	if name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Name is required")
+		return false
+	}
+	if connector == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Connector is required")
+		return false
+	}
+	return true
+}
+
+// sorted returns the objects of m ordered by ID, i.e. by creation.
+func sorted[T any](m map[string]*T) []*T {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	res := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, m[id])
+	}
+	return res
+}
+
+func filter[T any](items []*T, keep func(*T) bool) []T {
+	res := []T{}
+	for _, item := range items {
+		if keep(item) {
+			res = append(res, *item)
+		}
+	}
+	return res
+}
+
+// matches reports whether value passes the query filter want, which is
+// ignored when empty.
+func matches(want, value string) bool {
+	return want == "" || want == value
+}
+
+// hasTag reports whether tags contain one of the comma-separated tagIDs,
+// which are ignored when empty.
+func hasTag(tagIDs string, tags []string) bool {
+	if tagIDs == "" {
+		return true
+	}
+	for _, tagID := range strings.Split(tagIDs, ",") {
+		for _, tag := range tags {
+			if tag == tagID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writePage writes the page of items selected by the page and page_size
+// query parameters in the {"result": [...]} envelope of the API.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	q := r.URL.Query()
+	page, err := strconv.Atoi(q.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(q.Get("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	start := min((page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+	writeJSON(w, http.StatusOK, api.Page[T]{
+		Total:    len(items),
+		PageSize: pageSize,
+		Page:     page,
+		Result:   items[start:end],
+	})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON body: %s", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, api.APIErrorResponse{Detail: detail})
+}
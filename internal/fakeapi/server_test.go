@@ -0,0 +1,513 @@
+package fakeapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/helper"
+)
+
+func newClient(t *testing.T) (*Server, api.StreamkapAPI) {
+	t.Helper()
+
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(&api.Config{BaseURL: server.URL, MaxRetries: 0})
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	token, err := client.GetAccessToken(context.Background(), ClientID, Secret)
+	if err != nil {
+		t.Fatalf("unable to get access token: %s", err)
+	}
+	client.SetToken(token)
+
+	return server, client
+}
+
+func TestAccessToken(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(&api.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	if _, err := client.GetAccessToken(context.Background(), ClientID, "wrong"); !errors.Is(err, api.ErrUnauthorized) {
+		t.Errorf("expected invalid credentials to be rejected, got %v", err)
+	}
+	if _, err := client.GetSource(context.Background(), "src"); !errors.Is(err, api.ErrUnauthorized) {
+		t.Errorf("expected unauthenticated calls to be rejected, got %v", err)
+	}
+}
+
+func TestSourceLifecycle(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateSource(ctx, api.Source{
+		Name:      "pg",
+		Connector: "postgresql",
+		Config:    map[string]any{"database.hostname.user.defined": "db.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if created.ID == "" {
+		t.Fatal("expected an ID to be assigned")
+	}
+
+	created.Config["database.hostname.user.defined"] = "db2.example.com"
+	if _, err := client.UpdateSource(ctx, created.ID, *created); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := client.GetSource(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Config["database.hostname.user.defined"] != "db2.example.com" {
+		t.Errorf("expected the update to persist, got %v", got.Config)
+	}
+
+	if err := client.DeleteSource(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := client.GetSource(ctx, created.ID); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestSourceWithoutSecrets(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateSource(api.WithoutSecrets(ctx), api.Source{
+		Name:      "pg",
+		Connector: "postgresql",
+		Config:    map[string]any{"database.user": "streamkap", "database.password": "hunter2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := created.Config["database.password"]; ok || created.Config["database.user"] != "streamkap" {
+		t.Errorf("expected only the password to be left out, got %v", created.Config)
+	}
+
+	got, err := client.GetSource(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Config["database.password"] != "hunter2" {
+		t.Errorf("expected the password to be returned on request, got %v", got.Config)
+	}
+}
+
+func TestListSourcesPaginates(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	for i := 0; i < 25; i++ {
+		connector := "postgresql"
+		if i%5 == 0 {
+			connector = "mysql"
+		}
+		if _, err := client.CreateSource(ctx, api.Source{Name: "src", Connector: connector}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	all, err := client.ListSources(ctx, api.ListOptions{PageSize: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(all) != 25 {
+		t.Errorf("expected 25 sources, got %d", len(all))
+	}
+
+	mysql, err := client.ListSources(ctx, api.ListOptions{Connector: "mysql"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(mysql) != 5 {
+		t.Errorf("expected 5 mysql sources, got %d", len(mysql))
+	}
+}
+
+func TestPipelineReferences(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+
+	tagID := server.AddTag(api.Tag{Name: "prod"})
+	transformID := server.AddTransform(api.Transform{Name: "mask", TopicIDs: []string{"t1"}, Topics: []string{"public.users"}})
+
+	src, err := client.CreateSource(ctx, api.Source{Name: "src", Connector: "postgresql"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dst, err := client.CreateDestination(ctx, api.Destination{Name: "dst", Connector: "snowflake"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = client.CreatePipeline(ctx, api.Pipeline{Name: "p", Source: api.PipelineSource{ID: "missing"}, Destination: api.PipelineDestination{ID: dst.ID}})
+	if !errors.Is(err, api.ErrValidation) {
+		t.Errorf("expected an unknown source to be rejected, got %v", err)
+	}
+
+	pipeline, err := client.CreatePipeline(ctx, api.Pipeline{
+		Name:        "p",
+		Source:      api.PipelineSource{ID: src.ID},
+		Destination: api.PipelineDestination{ID: dst.ID},
+		Transforms:  []*api.PipelineTransform{{ID: transformID, TopicID: "t1", Topic: "public.users"}},
+		Tags:        []string{tagID},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := client.DeleteSource(ctx, src.ID); !errors.Is(err, api.ErrConflict) {
+		t.Errorf("expected a source used by a pipeline not to be deletable, got %v", err)
+	}
+
+	tagged, err := client.ListPipelines(ctx, api.ListOptions{TagID: tagID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tagged) != 1 || tagged[0].ID != pipeline.ID {
+		t.Fatalf("expected the tagged pipeline, got %+v", tagged)
+	}
+	if tagged[0].Source.Connector != "postgresql" || tagged[0].Destination.Connector != "snowflake" {
+		t.Errorf("expected the connectors to be echoed, got %+v", tagged[0])
+	}
+}
+
+func TestTopicPartitions(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+
+	server.AddTopic(api.Topic{TopicID: "source_1.public.users", PartitionCount: 3})
+
+	if _, err := client.UpdateTopic(ctx, "source_1.public.users", api.Topic{PartitionCount: 5}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	topic, err := client.GetTopic(ctx, "source_1.public.users")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if topic.PartitionCount != 5 {
+		t.Errorf("expected 5 partitions, got %d", topic.PartitionCount)
+	}
+
+	if _, err := client.UpdateTopic(ctx, "source_1.public.users", api.Topic{PartitionCount: 2}); !errors.Is(err, api.ErrValidation) {
+		t.Errorf("expected a partition shrink to be rejected, got %v", err)
+	}
+	if _, err := client.GetTopic(ctx, "missing"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTagLifecycle(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+
+	systemID := server.AddTag(api.Tag{Name: "Production", Type: []string{"pipelines"}, System: true})
+
+	tag, err := client.CreateTag(ctx, api.TagPayload{Name: "team-a", Type: []string{"pipelines"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tag.System || tag.Custom == nil || !*tag.Custom {
+		t.Errorf("expected a custom tag, got %+v", tag)
+	}
+
+	updated, err := client.UpdateTag(ctx, tag.ID, api.TagPayload{Name: "team-b", Type: []string{"pipelines"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if updated.Name != "team-b" {
+		t.Errorf("expected the tag to be renamed, got %q", updated.Name)
+	}
+
+	if _, err := client.UpdateTag(ctx, systemID, api.TagPayload{Name: "Prod", Type: []string{"pipelines"}}); !errors.Is(err, api.ErrUnauthorized) {
+		t.Errorf("expected system tags to be read-only, got %v", err)
+	}
+	if err := client.DeleteTag(ctx, systemID); !errors.Is(err, api.ErrUnauthorized) {
+		t.Errorf("expected system tags not to be deletable, got %v", err)
+	}
+
+	if err := client.DeleteTag(ctx, tag.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := client.GetTag(ctx, tag.ID); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestTransformLifecycle(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	if _, err := client.CreateTransform(ctx, api.Transform{Name: "mask"}); !errors.Is(err, api.ErrValidation) {
+		t.Errorf("expected an incomplete transform to be rejected, got %v", err)
+	}
+
+	transform, err := client.CreateTransform(ctx, api.Transform{
+		Name:              "mask",
+		TransformType:     "map_filter",
+		Language:          "JavaScript",
+		Code:              "function _streamkap_transform(obj) { return obj; }\n",
+		InputTopicPattern: "public\\.users",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if transform.Code != "function _streamkap_transform(obj) { return obj; }" {
+		t.Errorf("expected the code to be trimmed, got %q", transform.Code)
+	}
+	if transform.StartTime == nil {
+		t.Error("expected a start time to be filled in")
+	}
+
+	transform.Code = "function _streamkap_transform(obj) { obj.email = null; return obj; }"
+	if _, err := client.UpdateTransform(ctx, transform.ID, *transform); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := client.GetTransform(ctx, transform.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Code != transform.Code {
+		t.Errorf("expected the update to persist, got %q", got.Code)
+	}
+
+	if err := client.DeleteTransform(ctx, transform.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := client.GetTransform(ctx, transform.ID); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestTopicLifecycle(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	topic, err := client.CreateTopic(ctx, api.Topic{
+		TopicID:        "orders",
+		PartitionCount: 3,
+		Config:         map[string]string{api.TopicConfigRetentionMs: "86400000"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if topic.ReplicationFactor != 3 {
+		t.Errorf("expected the default replication factor, got %d", topic.ReplicationFactor)
+	}
+	if _, err := client.CreateTopic(ctx, api.Topic{TopicID: "orders", PartitionCount: 3}); !errors.Is(err, api.ErrConflict) {
+		t.Errorf("expected a duplicate topic to be rejected, got %v", err)
+	}
+
+	updated, err := client.UpdateTopic(ctx, "orders", api.Topic{
+		PartitionCount: 3,
+		Config:         map[string]string{api.TopicConfigCleanupPolicy: "compact"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The update returns the topic as applied, not as sent.
+	if updated.TopicID != "orders" || updated.ReplicationFactor != 3 || updated.Config[api.TopicConfigRetentionMs] != "86400000" {
+		t.Errorf("expected the applied topic, got %+v", updated)
+	}
+	got, err := client.GetTopic(ctx, "orders")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Config[api.TopicConfigRetentionMs] != "86400000" || got.Config[api.TopicConfigCleanupPolicy] != "compact" {
+		t.Errorf("expected the config to be merged, got %v", got.Config)
+	}
+
+	if err := client.DeleteTopic(ctx, "orders"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := client.GetTopic(ctx, "orders"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestSourceTopicsAreMaterialized(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	src, err := client.CreateSource(ctx, api.Source{
+		Name:      "pg",
+		Connector: "postgresql",
+		Config:    map[string]any{"table.include.list.user.defined": "public.users, public.orders"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	topics, err := client.ListTopics(ctx, api.ListOptions{SourceID: src.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(topics) != 2 {
+		t.Fatalf("expected 2 topics, got %+v", topics)
+	}
+	for _, topic := range topics {
+		if topic.TopicID != "source_"+src.ID+"."+topic.Name {
+			t.Errorf("unexpected topic ID %q for %q", topic.TopicID, topic.Name)
+		}
+	}
+}
+
+func TestPipelinePauseResume(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	src, err := client.CreateSource(ctx, api.Source{Name: "src", Connector: "postgresql"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dst, err := client.CreateDestination(ctx, api.Destination{Name: "dst", Connector: "snowflake"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pipeline, err := client.CreatePipeline(ctx, api.Pipeline{Name: "p", Source: api.PipelineSource{ID: src.ID}, Destination: api.PipelineDestination{ID: dst.ID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, step := range []struct {
+		action func(context.Context, string) error
+		want   string
+	}{
+		{client.PausePipeline, api.ConnectorStatusPaused},
+		{client.ResumePipeline, api.ConnectorStatusRunning},
+		{client.RestartPipeline, api.ConnectorStatusRunning},
+	} {
+		if err := step.action(ctx, pipeline.ID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		status, err := client.GetPipelineStatus(ctx, pipeline.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if status.State != step.want {
+			t.Errorf("expected %s, got %s", step.want, status.State)
+		}
+	}
+
+	if err := client.PausePipeline(ctx, "missing"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMissingStatus(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+
+	src, err := client.CreateSource(ctx, api.Source{Name: "src", Connector: "postgresql"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dst, err := client.CreateDestination(ctx, api.Destination{Name: "dst", Connector: "snowflake"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pipeline, err := client.CreatePipeline(ctx, api.Pipeline{Name: "p", Source: api.PipelineSource{ID: src.ID}, Destination: api.PipelineDestination{ID: dst.ID}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	server.mu.Lock()
+	delete(server.statuses, src.ID)
+	delete(server.statuses, pipeline.ID)
+	server.mu.Unlock()
+
+	if _, err := client.GetSourceStatus(ctx, src.ID); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := client.GetPipelineStatus(ctx, pipeline.ID); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := client.PausePipeline(ctx, pipeline.ID); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	pipeline.Name = "renamed"
+	if _, err := client.UpdatePipeline(ctx, pipeline.ID, *pipeline); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestSourceSnapshot(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	src, err := client.CreateSource(ctx, api.Source{Name: "src", Connector: "postgresql"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := client.TriggerSourceSnapshot(ctx, src.ID, api.SourceSnapshot{Type: api.SnapshotTypeIncremental}); err == nil {
+		t.Errorf("expected an error for a snapshot without tables")
+	}
+
+	snapshot, err := client.TriggerSourceSnapshot(ctx, src.ID, api.SourceSnapshot{Type: api.SnapshotTypeIncremental, Tables: []string{"public.users"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if snapshot.Status != api.SnapshotStatusPending {
+		t.Errorf("expected %s, got %s", api.SnapshotStatusPending, snapshot.Status)
+	}
+	for _, want := range []string{api.SnapshotStatusPending, api.SnapshotStatusRunning, api.SnapshotStatusCompleted, api.SnapshotStatusCompleted} {
+		got, err := client.GetSourceSnapshot(ctx, src.ID, snapshot.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.Status != want {
+			t.Errorf("expected %s, got %s", want, got.Status)
+		}
+	}
+
+	if _, err := client.GetSourceSnapshot(ctx, src.ID, "missing"); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWaitForRunning(t *testing.T) {
+	server, client := newClient(t)
+	ctx := context.Background()
+	helper.WaitPollMin = time.Millisecond
+	t.Cleanup(func() { helper.WaitPollMin = time.Second })
+
+	src, err := client.CreateSource(ctx, api.Source{Name: "src", Connector: "postgresql"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	status, err := helper.WaitForRunning(ctx, client.GetSourceStatus, src.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if status.State != api.ConnectorStatusRunning {
+		t.Errorf("expected %s, got %s", api.ConnectorStatusRunning, status.State)
+	}
+
+	server.SetStatus(src.ID, api.ConnectorStatus{State: api.ConnectorStatusFailed, Trace: "java.sql.SQLException: FATAL: password authentication failed"})
+	_, err = helper.WaitForRunning(ctx, client.GetSourceStatus, src.ID)
+	if err == nil || !strings.Contains(err.Error(), "password authentication failed") {
+		t.Errorf("expected the failure trace, got %v", err)
+	}
+
+	server.SetStatus(src.ID, api.ConnectorStatus{State: api.ConnectorStatusUnassigned})
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = helper.WaitForRunning(ctx, client.GetSourceStatus, src.ID)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
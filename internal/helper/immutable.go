@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+)
+
+// ImmutableSourceAttributes and ImmutableDestinationAttributes list, by
+// connector code, the attributes the backend cannot change on an existing
+// connector: it either rejects the update or the connector silently stops
+// replicating. Changing one of them replaces the connector instead.
+var (
+	ImmutableSourceAttributes = map[string][]string{
+		"dynamodb":     {"aws_region"},
+		"kafkadirect":  {"topic_prefix"},
+		"postgresql":   {"database_dbname", "slot_name", "publication_name"},
+		"sqlserveraws": {"database_dbname"},
+	}
+	ImmutableDestinationAttributes = map[string][]string{
+		"iceberg": {"catalog_type"},
+	}
+)
+
+// RequiresReplace adds a RequiresReplace plan modifier to the attributes of
+// the schema. It panics on an attribute the schema does not have, or of a
+// type it does not handle.
+func RequiresReplace(s *schema.Schema, attributes []string) {
+	for _, name := range attributes {
+		switch a := s.Attributes[name].(type) {
+		case schema.StringAttribute:
+			a.PlanModifiers = append(a.PlanModifiers, stringplanmodifier.RequiresReplace())
+			s.Attributes[name] = a
+		case schema.Int64Attribute:
+			a.PlanModifiers = append(a.PlanModifiers, int64planmodifier.RequiresReplace())
+			s.Attributes[name] = a
+		case schema.BoolAttribute:
+			a.PlanModifiers = append(a.PlanModifiers, boolplanmodifier.RequiresReplace())
+			s.Attributes[name] = a
+		default:
+			panic(fmt.Sprintf("cannot require replace of attribute %q of type %T", name, a))
+		}
+	}
+}
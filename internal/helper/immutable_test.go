@@ -0,0 +1,50 @@
+package helper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func TestRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"slot_name": schema.StringAttribute{Optional: true},
+			"port":      schema.Int64Attribute{Optional: true},
+			"enabled":   schema.BoolAttribute{Optional: true},
+			"host":      schema.StringAttribute{Optional: true},
+		},
+	}
+
+	RequiresReplace(&testSchema, []string{"slot_name", "port", "enabled"})
+
+	for name, want := range map[string]int{"slot_name": 1, "port": 1, "enabled": 1, "host": 0} {
+		var got int
+		switch a := testSchema.Attributes[name].(type) {
+		case schema.StringAttribute:
+			got = len(a.PlanModifiers)
+		case schema.Int64Attribute:
+			got = len(a.PlanModifiers)
+		case schema.BoolAttribute:
+			got = len(a.PlanModifiers)
+		}
+		if got != want {
+			t.Errorf("expected %d plan modifiers on %s, got %d", want, name, got)
+		}
+	}
+	if diags := testSchema.ValidateImplementation(ctx); diags.HasError() {
+		t.Errorf("unexpected schema errors: %v", diags)
+	}
+}
+
+func TestRequiresReplaceUnknownAttribute(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic on an attribute the schema does not have")
+		}
+	}()
+
+	RequiresReplace(&schema.Schema{Attributes: map[string]schema.Attribute{}}, []string{"slot_name"})
+}
@@ -0,0 +1,184 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	res "github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// ImportNamePrefix prefixes an import ID looking the object up by name
+// instead of ID, e.g. terraform import streamkap_source_postgresql.x
+// name:my-source.
+const ImportNamePrefix = "name:"
+
+// importedObject is the part of a source, destination or pipeline needed to
+// resolve an import ID.
+type importedObject struct {
+	ID        string
+	Name      string
+	Connector string
+}
+
+// ImportSourceState imports a source by ID or, with ImportNamePrefix, by
+// name, and fails when it is not a connector source.
+func ImportSourceState(ctx context.Context, client api.StreamkapAPI, connector string, req res.ImportStateRequest, resp *res.ImportStateResponse) {
+	importState(ctx, "source", connector, req, resp,
+		func(name string) ([]importedObject, error) {
+			sources, err := client.ListSources(ctx, api.ListOptions{Name: name})
+			objects := []importedObject{}
+			for _, source := range sources {
+				objects = append(objects, importedObject{source.ID, source.Name, source.Connector})
+			}
+			return objects, err
+		},
+		func(id string) (importedObject, error) {
+			source, err := client.GetSource(ctx, id)
+			if err != nil {
+				return importedObject{}, err
+			}
+			return importedObject{source.ID, source.Name, source.Connector}, nil
+		},
+	)
+}
+
+// ImportDestinationState imports a destination by ID or, with
+// ImportNamePrefix, by name, and fails when it is not a connector
+// destination.
+func ImportDestinationState(ctx context.Context, client api.StreamkapAPI, connector string, req res.ImportStateRequest, resp *res.ImportStateResponse) {
+	importState(ctx, "destination", connector, req, resp,
+		func(name string) ([]importedObject, error) {
+			destinations, err := client.ListDestinations(ctx, api.ListOptions{Name: name})
+			objects := []importedObject{}
+			for _, destination := range destinations {
+				objects = append(objects, importedObject{destination.ID, destination.Name, destination.Connector})
+			}
+			return objects, err
+		},
+		func(id string) (importedObject, error) {
+			destination, err := client.GetDestination(ctx, id)
+			if err != nil {
+				return importedObject{}, err
+			}
+			return importedObject{destination.ID, destination.Name, destination.Connector}, nil
+		},
+	)
+}
+
+// ImportPipelineState imports a pipeline by ID or, with ImportNamePrefix, by
+// name.
+func ImportPipelineState(ctx context.Context, client api.StreamkapAPI, req res.ImportStateRequest, resp *res.ImportStateResponse) {
+	if !strings.HasPrefix(req.ID, ImportNamePrefix) {
+		res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	importState(ctx, "pipeline", "", req, resp,
+		func(name string) ([]importedObject, error) {
+			pipelines, err := client.ListPipelines(ctx, api.ListOptions{Name: name})
+			objects := []importedObject{}
+			for _, pipeline := range pipelines {
+				objects = append(objects, importedObject{ID: pipeline.ID, Name: pipeline.Name})
+			}
+			return objects, err
+		},
+		nil,
+	)
+}
+
+// importState sets the id of the imported object of the given kind, looked
+// up by name with list or by ID with get. A non-empty connector must match
+// the connector of the object.
+func importState(
+	ctx context.Context,
+	kind, connector string,
+	req res.ImportStateRequest,
+	resp *res.ImportStateResponse,
+	list func(name string) ([]importedObject, error),
+	get func(id string) (importedObject, error),
+) {
+	var object importedObject
+	if name, ok := strings.CutPrefix(req.ID, ImportNamePrefix); ok {
+		objects, err := list(name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Error importing %s", kind),
+				fmt.Sprintf("Unable to list %ss, got error: %s", kind, err),
+			)
+			return
+		}
+		var diags diag.Diagnostics
+		object, diags = importedByName(kind, name, objects)
+		resp.Diagnostics.Append(diags...)
+	} else {
+		var err error
+		object, err = get(req.ID)
+		switch {
+		case errors.Is(err, api.ErrNotFound):
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Error importing %s", kind),
+				fmt.Sprintf("No %s has the ID %q. To import a %s by name, use %s<name>.", kind, req.ID, kind, ImportNamePrefix),
+			)
+		case err != nil:
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Error importing %s", kind),
+				fmt.Sprintf("Unable to read %s %s, got error: %s", kind, req.ID, err),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if connector != "" && object.Connector != connector {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error importing %s", kind),
+			fmt.Sprintf("%s %q (%s) is a %s %s, it cannot be imported into a %s %s resource",
+				strings.ToUpper(kind[:1])+kind[1:], object.Name, object.ID, object.Connector, kind, connector, kind),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), object.ID)...)
+}
+
+// importedByName returns the only object of objects named name, which the
+// API may match loosely, or an error when there is none or several.
+func importedByName(kind, name string, objects []importedObject) (importedObject, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	matches := []importedObject{}
+	for _, object := range objects {
+		if object.Name == name {
+			matches = append(matches, object)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], diags
+	case 0:
+		diags.AddError(
+			fmt.Sprintf("Error importing %s", kind),
+			fmt.Sprintf("No %s is named %q", kind, name),
+		)
+	default:
+		found := []string{}
+		for _, match := range matches {
+			if match.Connector != "" {
+				found = append(found, fmt.Sprintf("%s (%s)", match.ID, match.Connector))
+			} else {
+				found = append(found, match.ID)
+			}
+		}
+		diags.AddError(
+			fmt.Sprintf("Error importing %s", kind),
+			fmt.Sprintf("%d %ss are named %q (%s), import it by ID instead", len(matches), kind, name, strings.Join(found, ", ")),
+		)
+	}
+	return importedObject{}, diags
+}
@@ -0,0 +1,91 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	res "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+func TestImportState(t *testing.T) {
+	ctx := context.Background()
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true},
+		},
+	}
+	// The API matches names loosely, e.g. my-source-2 for my-source.
+	sources := []importedObject{
+		{"1", "my-source", "postgresql"},
+		{"2", "my-source-2", "postgresql"},
+		{"3", "my-mysql", "mysql"},
+		{"4", "twin", "postgresql"},
+		{"5", "twin", "mysql"},
+	}
+	list := func(name string) ([]importedObject, error) {
+		matches := []importedObject{}
+		for _, source := range sources {
+			if strings.Contains(source.Name, name) {
+				matches = append(matches, source)
+			}
+		}
+		return matches, nil
+	}
+	get := func(id string) (importedObject, error) {
+		for _, source := range sources {
+			if source.ID == id {
+				return source, nil
+			}
+		}
+		return importedObject{}, fmt.Errorf("source %s: %w", id, api.ErrNotFound)
+	}
+
+	for name, test := range map[string]struct {
+		importID  string
+		wantID    string
+		wantError string
+	}{
+		"by id":               {importID: "1", wantID: "1"},
+		"by id not found":     {importID: "9", wantError: `No source has the ID "9"`},
+		"by id wrong type":    {importID: "3", wantError: "is a mysql source, it cannot be imported into a postgresql source resource"},
+		"by name":             {importID: "name:my-source", wantID: "1"},
+		"by name not found":   {importID: "name:nope", wantError: `No source is named "nope"`},
+		"by name ambiguous":   {importID: "name:twin", wantError: `2 sources are named "twin" (4 (postgresql), 5 (mysql))`},
+		"by name wrong type":  {importID: "name:my-mysql", wantError: "is a mysql source"},
+		"by name prefix only": {importID: "name:", wantError: `No source is named ""`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &res.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: testSchema,
+					Raw:    tftypes.NewValue(testSchema.Type().TerraformType(ctx), nil),
+				},
+			}
+			importState(ctx, "source", "postgresql", res.ImportStateRequest{ID: test.importID}, resp, list, get)
+
+			if test.wantError != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), test.wantError) {
+					t.Fatalf("expected error %q, got %v", test.wantError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if id.ValueString() != test.wantID {
+				t.Errorf("expected id %q, got %q", test.wantID, id.ValueString())
+			}
+		})
+	}
+}
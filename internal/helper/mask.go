@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"context"
+	"reflect"
+
+	res "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// MaskSensitiveValues returns a context masking, in every log written with it,
+// the values of the string attributes of a resource model that the resource
+// schema marks as Sensitive.
+func MaskSensitiveValues(ctx context.Context, r res.Resource, model any) context.Context {
+	v := reflect.Indirect(reflect.ValueOf(model))
+	if v.Kind() != reflect.Struct {
+		return ctx
+	}
+
+	resp := &res.SchemaResponse{}
+	r.Schema(ctx, res.SchemaRequest{}, resp)
+	sensitive := SensitiveAttributes(resp.Schema)
+
+	secrets := []string{}
+	for i := 0; i < v.NumField(); i++ {
+		if !sensitive[v.Type().Field(i).Tag.Get("tfsdk")] {
+			continue
+		}
+		if value, ok := v.Field(i).Interface().(types.String); ok && value.ValueString() != "" {
+			secrets = append(secrets, value.ValueString())
+		}
+	}
+
+	if len(secrets) == 0 {
+		return ctx
+	}
+	return tflog.MaskLogStrings(ctx, secrets...)
+}
+
+// SensitiveAttributes returns the names of the top-level attributes of the
+// schema marked as Sensitive.
+func SensitiveAttributes(s schema.Schema) map[string]bool {
+	sensitive := map[string]bool{}
+	for name, attribute := range s.Attributes {
+		if attribute.IsSensitive() {
+			sensitive[name] = true
+		}
+	}
+	return sensitive
+}
@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	res "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+type maskTestResource struct{}
+
+func (maskTestResource) Metadata(context.Context, res.MetadataRequest, *res.MetadataResponse) {}
+func (maskTestResource) Create(context.Context, res.CreateRequest, *res.CreateResponse)       {}
+func (maskTestResource) Read(context.Context, res.ReadRequest, *res.ReadResponse)             {}
+func (maskTestResource) Update(context.Context, res.UpdateRequest, *res.UpdateResponse)       {}
+func (maskTestResource) Delete(context.Context, res.DeleteRequest, *res.DeleteResponse)       {}
+
+func (maskTestResource) Schema(_ context.Context, _ res.SchemaRequest, resp *res.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"connection_url": schema.StringAttribute{Optional: true, Sensitive: true},
+			"password_hint":  schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+type maskTestModel struct {
+	ConnectionURL types.String `tfsdk:"connection_url"`
+	PasswordHint  types.String `tfsdk:"password_hint"`
+}
+
+func TestMaskSensitiveValues(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	ctx = MaskSensitiveValues(ctx, maskTestResource{}, maskTestModel{
+		ConnectionURL: types.StringValue("jdbc://user:hunter2@db"),
+		PasswordHint:  types.StringValue("favourite-colour"),
+	})
+	tflog.Info(ctx, "connecting to jdbc://user:hunter2@db, hint favourite-colour")
+
+	if strings.Contains(output.String(), "hunter2") {
+		t.Errorf("expected the Sensitive attribute to be masked, got %s", output.String())
+	}
+	if !strings.Contains(output.String(), "favourite-colour") {
+		t.Errorf("expected the other attributes to be logged, got %s", output.String())
+	}
+}
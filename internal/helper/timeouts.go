@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// DefaultTimeout bounds a create, update or delete when the timeouts block
+// of the resource does not set it.
+const DefaultTimeout = 20 * time.Minute
+
+// TimeoutsBlock is the timeouts block shared by every resource.
+func TimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// WaitForRunningAttribute is the wait_for_running attribute of the sources,
+// destinations and pipelines.
+func WaitForRunningAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		Description: "Wait for the connector to be running after a create or update, " +
+			"within the create or update timeout. Default is false.",
+		MarkdownDescription: "Wait for the connector to be `RUNNING` after a create or update, " +
+			"within the `create` or `update` timeout. Default is `false`.",
+	}
+}
+
+// WaitForRunning polls the status of a connector until it is RUNNING. A
+// connector that is FAILED fails with its trace.
+func WaitForRunning(ctx context.Context, getStatus func(context.Context, string) (*api.ConnectorStatus, error), id string) (*api.ConnectorStatus, error) {
+	var status *api.ConnectorStatus
+	err := WaitFor(ctx, func(ctx context.Context) (bool, error) {
+		var err error
+		status, err = getStatus(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		if status.State == api.ConnectorStatusFailed {
+			return false, fmt.Errorf("connector %s failed:\n%s", id, status.Trace)
+		}
+		return status.State == api.ConnectorStatusRunning, nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) && status != nil {
+		return status, fmt.Errorf("timed out waiting for connector %s to be running, it is %s", id, status.State)
+	}
+
+	return status, err
+}
@@ -0,0 +1,81 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RequiredWhenTrue returns a config validator requiring the attributes to be
+// set, and not empty, when the bool attribute flag is true, e.g. ssh_host
+// when ssh_enabled is true.
+func RequiredWhenTrue(flag string, attributes ...string) resource.ConfigValidator {
+	return flagValidator{flag: flag, attributes: attributes, required: true}
+}
+
+// OnlyWhenTrue returns a config validator rejecting the attributes, unless
+// empty, when the bool attribute flag is not true, e.g. a heartbeat schema
+// while heartbeat_enabled is false. A null flag counts as false.
+func OnlyWhenTrue(flag string, attributes ...string) resource.ConfigValidator {
+	return flagValidator{flag: flag, attributes: attributes}
+}
+
+type flagValidator struct {
+	flag       string
+	attributes []string
+	required   bool
+}
+
+func (v flagValidator) Description(ctx context.Context) string {
+	if v.required {
+		return fmt.Sprintf("%v must be set when %s is true", v.attributes, v.flag)
+	}
+	return fmt.Sprintf("%v can only be set when %s is true", v.attributes, v.flag)
+}
+
+func (v flagValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v flagValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var flag types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(v.flag), &flag)...)
+	if resp.Diagnostics.HasError() || flag.IsUnknown() {
+		return
+	}
+	// RequiredWhenTrue checks the attributes when the flag is true,
+	// OnlyWhenTrue when it is not.
+	if v.required != flag.ValueBool() {
+		return
+	}
+
+	for _, attribute := range v.attributes {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if value.IsUnknown() {
+			continue
+		}
+
+		set := !value.IsNull() && value.ValueString() != ""
+		switch {
+		case v.required && !set:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Missing Attribute Configuration",
+				fmt.Sprintf("%s must be set when %s is true.", attribute, v.flag),
+			)
+		case !v.required && set:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("%s can only be set when %s is true.", attribute, v.flag),
+			)
+		}
+	}
+}
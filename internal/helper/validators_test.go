@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFlagValidators(t *testing.T) {
+	ctx := context.Background()
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{Optional: true},
+			"host":    schema.StringAttribute{Optional: true},
+		},
+	}
+	config := func(enabled, host any) tfsdk.Config {
+		return tfsdk.Config{
+			Schema: testSchema,
+			Raw: tftypes.NewValue(testSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"enabled": tftypes.NewValue(tftypes.Bool, enabled),
+				"host":    tftypes.NewValue(tftypes.String, host),
+			}),
+		}
+	}
+
+	for name, test := range map[string]struct {
+		validator resource.ConfigValidator
+		config    tfsdk.Config
+		wantError bool
+	}{
+		"required set":            {RequiredWhenTrue("enabled", "host"), config(true, "bastion"), false},
+		"required missing":        {RequiredWhenTrue("enabled", "host"), config(true, nil), true},
+		"required empty":          {RequiredWhenTrue("enabled", "host"), config(true, ""), true},
+		"required disabled":       {RequiredWhenTrue("enabled", "host"), config(false, nil), false},
+		"required flag unknown":   {RequiredWhenTrue("enabled", "host"), config(tftypes.UnknownValue, nil), false},
+		"required value unknown":  {RequiredWhenTrue("enabled", "host"), config(true, tftypes.UnknownValue), false},
+		"only when enabled":       {OnlyWhenTrue("enabled", "host"), config(true, "bastion"), false},
+		"only when disabled":      {OnlyWhenTrue("enabled", "host"), config(false, "bastion"), true},
+		"only when flag null":     {OnlyWhenTrue("enabled", "host"), config(nil, "bastion"), true},
+		"only when disabled null": {OnlyWhenTrue("enabled", "host"), config(false, nil), false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			test.validator.ValidateResource(ctx, resource.ValidateConfigRequest{Config: test.config}, resp)
+			if got := resp.Diagnostics.HasError(); got != test.wantError {
+				t.Errorf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"context"
+	"time"
+)
+
+// WaitPollMin and WaitPollMax bound the backoff between two polls of
+// WaitFor.
+var (
+	WaitPollMin = time.Second
+	WaitPollMax = 30 * time.Second
+)
+
+// WaitFor calls check until it reports done or fails, doubling the pause
+// between two calls from WaitPollMin up to WaitPollMax. It returns
+// ctx.Err() once ctx is done.
+func WaitFor(ctx context.Context, check func(ctx context.Context) (done bool, err error)) error {
+	wait := WaitPollMin
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		wait = min(2*wait, WaitPollMax)
+	}
+}
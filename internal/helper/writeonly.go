@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+)
+
+// WriteOnlyAttribute is the write-only variant, <name>_wo, of the sensitive
+// attribute name. Terraform never saves it in the plan or state, so it cannot
+// plan an update when only the value changes: changing <name>_wo_version
+// does. The value read from the config is sent with every create and update.
+func WriteOnlyAttribute(name string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:  true,
+		Sensitive: true,
+		WriteOnly: true,
+		Description: "Write-only variant of " + name + ", never stored in the plan or state. " +
+			"Requires Terraform 1.11 or later and " + name + "_wo_version.",
+		MarkdownDescription: "Write-only variant of `" + name + "`, never stored in the plan or state. " +
+			"Requires Terraform 1.11 or later and `" + name + "_wo_version`.",
+		Validators: []validator.String{
+			stringvalidator.AlsoRequires(path.MatchRoot(name + "_wo_version")),
+		},
+	}
+}
+
+// WriteOnlyVersionAttribute is the <name>_wo_version attribute: changing it
+// plans an update, which sends the current value of <name>_wo to the API.
+func WriteOnlyVersionAttribute(name string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional: true,
+		Description: "Version of " + name + "_wo. Terraform cannot tell when a write-only value changes, " +
+			"change this version to plan an update sending the new value. The current value is also sent with any other update.",
+		MarkdownDescription: "Version of `" + name + "_wo`. Terraform cannot tell when a write-only value changes, " +
+			"change this version to plan an update sending the new value. The current value is also sent with any other update.",
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRoot(name + "_wo")),
+		},
+	}
+}
+
+// PreferWriteOnly returns the write-only variant of a secret when it is set,
+// the plain attribute otherwise.
+func PreferWriteOnly(writeOnly, plain types.String) types.String {
+	if !writeOnly.IsNull() {
+		return writeOnly
+	}
+	return plain
+}
+
+// UsesWriteOnly reports whether a resource model sets the version of one of
+// its write-only secrets, i.e. uses write-only secrets.
+func UsesWriteOnly(model any) bool {
+	v := reflect.Indirect(reflect.ValueOf(model))
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if !strings.HasSuffix(v.Type().Field(i).Tag.Get("tfsdk"), "_wo_version") {
+			continue
+		}
+		if version, ok := v.Field(i).Interface().(types.Int64); ok && !version.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutSecretsWhenWriteOnly returns a context asking the API not to return
+// the secrets of a resource model using write-only secrets, so that they do
+// not end up in the state.
+func WithoutSecretsWhenWriteOnly(ctx context.Context, model any) context.Context {
+	if !UsesWriteOnly(model) {
+		return ctx
+	}
+	return api.WithoutSecrets(ctx)
+}
@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUsesWriteOnly(t *testing.T) {
+	type model struct {
+		Password            types.String `tfsdk:"database_password"`
+		PasswordWO          types.String `tfsdk:"database_password_wo"`
+		PasswordWOVersion   types.Int64  `tfsdk:"database_password_wo_version"`
+		SnapshotParallelism types.Int64  `tfsdk:"snapshot_parallelism"`
+	}
+
+	for name, test := range map[string]struct {
+		model model
+		want  bool
+	}{
+		"plain":      {model{Password: types.StringValue("hunter2"), SnapshotParallelism: types.Int64Value(1)}, false},
+		"write-only": {model{PasswordWOVersion: types.Int64Value(1)}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := UsesWriteOnly(test.model); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+			if got := UsesWriteOnly(&test.model); got != test.want {
+				t.Errorf("expected %t for a pointer, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestPreferWriteOnly(t *testing.T) {
+	if got := PreferWriteOnly(types.StringValue("wo"), types.StringNull()); got.ValueString() != "wo" {
+		t.Errorf("expected the write-only value, got %s", got)
+	}
+	if got := PreferWriteOnly(types.StringNull(), types.StringValue("plain")); got.ValueString() != "plain" {
+		t.Errorf("expected the plain value, got %s", got)
+	}
+}
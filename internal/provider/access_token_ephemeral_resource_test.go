@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccAccessTokenEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		// The echo provider saves the ephemeral result in its state, so that
+		// the test can check it.
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"streamkap": testAccProtoV6ProviderFactories["streamkap"],
+			"echo":      echoprovider.NewProviderServer(),
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+ephemeral "streamkap_access_token" "test" {}
+
+provider "echo" {
+	data = ephemeral.streamkap_access_token.test
+}
+
+resource "echo" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.test", "data.access_token"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.expires_at"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.expires_in"),
+				),
+			},
+		},
+	})
+}
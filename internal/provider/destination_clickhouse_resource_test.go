@@ -1,16 +1,15 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // Define environment variables for ClickHouse configuration
-var destinationClickHouseHostname = os.Getenv("TF_VAR_destination_clickhouse_hostname")
-var destinationClickHouseUsername = os.Getenv("TF_VAR_destination_clickhouse_connection_username")
-var destinationClickHousePassword = os.Getenv("TF_VAR_destination_clickhouse_connection_password")
+var destinationClickHouseHostname = testAccVar("destination_clickhouse_hostname")
+var destinationClickHouseUsername = testAccVar("destination_clickhouse_connection_username")
+var destinationClickHousePassword = testAccVar("destination_clickhouse_connection_password")
 
 func TestAccDestinationClickHouseResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
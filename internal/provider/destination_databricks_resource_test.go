@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // Define environment variables for Databricks configuration
-var destinationDatabricksConnectionUrl = os.Getenv("TF_VAR_destination_databricks_connection_url")
-var destinationDatabricksToken = os.Getenv("TF_VAR_destination_databricks_token")
+var destinationDatabricksConnectionUrl = testAccVar("destination_databricks_connection_url")
+var destinationDatabricksToken = testAccVar("destination_databricks_token")
 
 func TestAccDestinationDatabricksResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
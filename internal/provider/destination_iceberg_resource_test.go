@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // Define environment variables for Iceberg configuration
-var icebergAwsAccessKey = os.Getenv("TF_VAR_iceberg_aws_access_key")
-var icebergAwsSecretKey = os.Getenv("TF_VAR_iceberg_aws_secret_key")
+var icebergAwsAccessKey = testAccVar("iceberg_aws_access_key")
+var icebergAwsSecretKey = testAccVar("iceberg_aws_secret_key")
 
 func TestAccDestinationIcebergResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
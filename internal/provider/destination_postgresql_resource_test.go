@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // Define environment variables for Postgresql configuration
-var destinationPostgresqlHostname = os.Getenv("TF_VAR_destination_postgresql_hostname")
-var destinationPostgresqlPassword = os.Getenv("TF_VAR_destination_postgresql_password")
+var destinationPostgresqlHostname = testAccVar("destination_postgresql_hostname")
+var destinationPostgresqlPassword = testAccVar("destination_postgresql_password")
 
 func TestAccDestinationPostgresqlResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
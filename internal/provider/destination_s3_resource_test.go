@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // Define environment variables for S3 configuration
-var s3AwsAccessKey = os.Getenv("TF_VAR_s3_aws_access_key")
-var s3AwsSecretKey = os.Getenv("TF_VAR_s3_aws_secret_key")
+var s3AwsAccessKey = testAccVar("s3_aws_access_key")
+var s3AwsSecretKey = testAccVar("s3_aws_secret_key")
 
 func TestAccDestinationS3Resource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
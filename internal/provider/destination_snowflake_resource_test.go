@@ -1,16 +1,15 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-var destinationSnowflakeURLName = os.Getenv("TF_VAR_destination_snowflake_url_name")
-var destinationSnowflakePrivateKey = os.Getenv("TF_VAR_destination_snowflake_private_key")
-var destinationSnowflakeKeyPassphrase = os.Getenv("TF_VAR_destination_snowflake_key_passphrase")
-var destinationSnowflakePrivateKeyNoCrypt = os.Getenv("TF_VAR_destination_snowflake_private_key_nocrypt")
+var destinationSnowflakeURLName = testAccVar("destination_snowflake_url_name")
+var destinationSnowflakePrivateKey = testAccVar("destination_snowflake_private_key")
+var destinationSnowflakeKeyPassphrase = testAccVar("destination_snowflake_key_passphrase")
+var destinationSnowflakePrivateKeyNoCrypt = testAccVar("destination_snowflake_private_key_nocrypt")
 
 func TestAccDestinationSnowflakeResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
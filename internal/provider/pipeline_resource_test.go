@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -152,6 +154,13 @@ resource "streamkap_pipeline" "test" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name testing
+			{
+				ResourceName:      "streamkap_pipeline.test",
+				ImportState:       true,
+				ImportStateId:     "name:test-pipeline",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + pipelineSrcPostgreSQLResourceDef + pipelineDestSnowflakeResourceDef + pipelineTransformsDef + pipelineTagsDef + `
@@ -195,6 +204,184 @@ resource "streamkap_pipeline" "test" {
 	})
 }
 
+func TestAccPipelineResourceTransformsByName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Transforms referenced by name and topic pattern
+			{
+				Config: providerConfig + pipelineSrcPostgreSQLResourceDef + pipelineDestSnowflakeResourceDef + `
+resource "streamkap_pipeline" "test" {
+	name = "test-pipeline-transforms"
+	source = {
+		id        = streamkap_source_postgresql.test.id
+		name      = streamkap_source_postgresql.test.name
+		connector = streamkap_source_postgresql.test.connector
+		topics    = [
+			"streamkap.customer",
+		]
+	}
+	destination = {
+		id        = streamkap_destination_snowflake.test.id
+		name      = streamkap_destination_snowflake.test.name
+		connector = streamkap_destination_snowflake.test.connector
+	}
+	transforms = [
+		{
+			name          = "test-transform"
+			topic_pattern = "public.*"
+		},
+		{
+			name          = "another-test-transform"
+			topic_pattern = "te(s|x)t"
+		},
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "transforms.0.id", "67d43b4ed21e8f093edae34b"),
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "transforms.0.topics.#", "1"),
+					resource.TestCheckTypeSetElemAttr("streamkap_pipeline.test", "transforms.0.topics.*", "public.test_transformed"),
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "transforms.1.id", "67dbe945308e0871a4e1fc49"),
+					resource.TestCheckTypeSetElemAttr("streamkap_pipeline.test", "transforms.1.topics.*", "test"),
+				),
+			},
+			// Unmatched patterns fail at plan time
+			{
+				Config: providerConfig + pipelineSrcPostgreSQLResourceDef + pipelineDestSnowflakeResourceDef + `
+resource "streamkap_pipeline" "test" {
+	name = "test-pipeline-transforms"
+	source = {
+		id        = streamkap_source_postgresql.test.id
+		name      = streamkap_source_postgresql.test.name
+		connector = streamkap_source_postgresql.test.connector
+		topics    = [
+			"streamkap.customer",
+		]
+	}
+	destination = {
+		id        = streamkap_destination_snowflake.test.id
+		name      = streamkap_destination_snowflake.test.name
+		connector = streamkap_destination_snowflake.test.connector
+	}
+	transforms = [
+		{
+			name          = "test-transform"
+			topic_pattern = "private.*"
+		},
+	]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Unmatched transform topic patterns"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPipelineStateConfig(state, schemaVersion string) string {
+	return providerConfig + pipelineSrcPostgreSQLResourceDef + pipelineDestSnowflakeResourceDef + fmt.Sprintf(`
+resource "streamkap_pipeline" "test" {
+	name = "test-pipeline-state"
+	source = {
+		id        = streamkap_source_postgresql.test.id
+		name      = streamkap_source_postgresql.test.name
+		connector = streamkap_source_postgresql.test.connector
+		topics    = [
+			"streamkap.customer",
+		]
+	}
+	destination = {
+		id        = streamkap_destination_snowflake.test.id
+		name      = streamkap_destination_snowflake.test.name
+		connector = streamkap_destination_snowflake.test.connector
+	}
+	state = %[1]q
+	restart_on_change = {
+		schema_version = %[2]q
+	}
+}
+`, state, schemaVersion)
+}
+
+func TestAccPipelineResourceState(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create paused
+			{
+				Config: testAccPipelineStateConfig("paused", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "state", "paused"),
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "status", "PAUSED"),
+				),
+			},
+			// Resume
+			{
+				Config: testAccPipelineStateConfig("running", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "state", "running"),
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "status", "RUNNING"),
+				),
+			},
+			// Restart on change
+			{
+				Config: testAccPipelineStateConfig("running", "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "restart_on_change.schema_version", "2"),
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "status", "RUNNING"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "streamkap_pipeline.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"restart_on_change"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccPipelineResourceWaitForRunning(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + pipelineSrcPostgreSQLResourceDef + pipelineDestSnowflakeResourceDef + `
+resource "streamkap_pipeline" "test" {
+	name = "test-pipeline-wait"
+	source = {
+		id        = streamkap_source_postgresql.test.id
+		name      = streamkap_source_postgresql.test.name
+		connector = streamkap_source_postgresql.test.connector
+		topics    = [
+			"streamkap.customer",
+		]
+	}
+	destination = {
+		id        = streamkap_destination_snowflake.test.id
+		name      = streamkap_destination_snowflake.test.name
+		connector = streamkap_destination_snowflake.test.connector
+	}
+	wait_for_running = true
+	timeouts {
+		create = "15m"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "status", "RUNNING"),
+					resource.TestCheckResourceAttr("streamkap_pipeline.test", "timeouts.create", "15m"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
 // Test DynamoDB -> ClickHouse ----------------------------------------------------------
 var pipelineSrcDynamoDBResourceDef = `
 variable "source_dynamodb_aws_region" {
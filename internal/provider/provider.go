@@ -2,29 +2,39 @@ package provider
 
 import (
 	"context"
-	// "fmt"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	eph "github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	// "github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
 	ds "github.com/streamkap-com/terraform-provider-streamkap/internal/datasource"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/ephemeral"
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/resource/destination"
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/resource/pipeline"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/resource/snapshot"
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/resource/source"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/resource/tag"
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/resource/topic"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/resource/transform"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &streamkapProvider{}
+	_ provider.Provider                       = &streamkapProvider{}
+	_ provider.ProviderWithEphemeralResources = &streamkapProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -49,6 +59,27 @@ type streamkapProviderModel struct {
 	Host     types.String `tfsdk:"host"`
 	ClientID types.String `tfsdk:"client_id"`
 	Secret   types.String `tfsdk:"secret"`
+
+	Token           types.String `tfsdk:"token"`
+	CredentialsFile types.String `tfsdk:"credentials_file"`
+	TokenTimeout    types.Int64  `tfsdk:"token_timeout"`
+
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryWaitMin types.Int64 `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int64 `tfsdk:"retry_wait_max"`
+
+	MaxRequestsPerSecond  types.Int64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	HTTPProxy          types.String `tfsdk:"http_proxy"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.Int64  `tfsdk:"request_timeout"`
+
+	UserAgentSuffix types.String `tfsdk:"user_agent_suffix"`
 }
 
 // Metadata returns the provider type name.
@@ -77,6 +108,115 @@ func (p *streamkapProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token": schema.StringAttribute{
+				Description:         "A pre-issued Streamkap API bearer token, used instead of exchanging client_id and secret. It is not refreshed, so it must outlive the Terraform run. If not set, Streamkap will use environment variable `STREAMKAP_TOKEN`",
+				MarkdownDescription: "A pre-issued Streamkap API bearer token, used instead of exchanging `client_id` and `secret`. It is not refreshed, so it must outlive the Terraform run. If not set, Streamkap will use environment variable `STREAMKAP_TOKEN`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"credentials_file": schema.StringAttribute{
+				Description:         "Path to a JSON file holding the Streamkap API credentials, e.g. {\"client_id\": \"...\", \"secret\": \"...\"}. client_id and secret set in the configuration or environment take precedence. If not set, Streamkap will use environment variable `STREAMKAP_CREDENTIALS_FILE`",
+				MarkdownDescription: "Path to a JSON file holding the Streamkap API credentials, e.g. `{\"client_id\": \"...\", \"secret\": \"...\"}`. `client_id` and `secret` set in the configuration or environment take precedence. If not set, Streamkap will use environment variable `STREAMKAP_CREDENTIALS_FILE`",
+				Optional:            true,
+			},
+			"token_timeout": schema.Int64Attribute{
+				Description:         fmt.Sprintf("Timeout in seconds of an access token exchange, retries included. Defaults to %d.", int64(api.DefaultTokenTimeout/time.Second)),
+				MarkdownDescription: fmt.Sprintf("Timeout in seconds of an access token exchange, retries included. Defaults to `%d`.", int64(api.DefaultTokenTimeout/time.Second)),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description:         fmt.Sprintf("Maximum number of times a throttled (429) or unavailable (502, 503, 504) API call, or a dropped connection, is retried. Set to 0 to disable retries. Defaults to %d.", api.DefaultMaxRetries),
+				MarkdownDescription: fmt.Sprintf("Maximum number of times a throttled (`429`) or unavailable (`502`, `503`, `504`) API call, or a dropped connection, is retried. Set to `0` to disable retries. Defaults to `%d`.", api.DefaultMaxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.Int64Attribute{
+				Description:         fmt.Sprintf("Minimum time in seconds to wait before retrying an API call. Defaults to %d.", int64(api.DefaultRetryWaitMin/time.Second)),
+				MarkdownDescription: fmt.Sprintf("Minimum time in seconds to wait before retrying an API call. Defaults to `%d`.", int64(api.DefaultRetryWaitMin/time.Second)),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				Description:         fmt.Sprintf("Maximum time in seconds to wait before retrying an API call. A `Retry-After` header sent by the API is honored up to this maximum. Defaults to %d.", int64(api.DefaultRetryWaitMax/time.Second)),
+				MarkdownDescription: fmt.Sprintf("Maximum time in seconds to wait before retrying an API call. A `Retry-After` header sent by the API is honored up to this maximum. Defaults to `%d`.", int64(api.DefaultRetryWaitMax/time.Second)),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_requests_per_second": schema.Int64Attribute{
+				Description:         fmt.Sprintf("Maximum number of API calls per second, retries included. Calls over the limit wait in a queue. Set to 0 to disable the limit. Defaults to %d.", api.DefaultMaxRequestsPerSecond),
+				MarkdownDescription: fmt.Sprintf("Maximum number of API calls per second, retries included. Calls over the limit wait in a queue. Set to `0` to disable the limit. Defaults to `%d`.", api.DefaultMaxRequestsPerSecond),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description:         fmt.Sprintf("Maximum number of API calls in flight at once, whatever Terraform's -parallelism. Set to 0 to disable the limit. Defaults to %d.", api.DefaultMaxConcurrentRequests),
+				MarkdownDescription: fmt.Sprintf("Maximum number of API calls in flight at once, whatever Terraform's `-parallelism`. Set to `0` to disable the limit. Defaults to `%d`.", api.DefaultMaxConcurrentRequests),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"http_proxy": schema.StringAttribute{
+				Description:         "URL of the HTTP proxy used to reach the Streamkap API, e.g. http://proxy.example.com:3128. If not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used.",
+				MarkdownDescription: "URL of the HTTP proxy used to reach the Streamkap API, e.g. `http://proxy.example.com:3128`. If not set, the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables are used.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description:         "Path to a PEM-encoded CA bundle trusted in addition to the system roots, e.g. for a TLS-inspecting corporate proxy. If not set, Streamkap will use environment variable `STREAMKAP_CA_CERT_FILE`",
+				MarkdownDescription: "Path to a PEM-encoded CA bundle trusted in addition to the system roots, e.g. for a TLS-inspecting corporate proxy. If not set, Streamkap will use environment variable `STREAMKAP_CA_CERT_FILE`",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description:         "PEM-encoded CA certificates trusted in addition to the system roots.",
+				MarkdownDescription: "PEM-encoded CA certificates trusted in addition to the system roots.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description:         "PEM-encoded client certificate presented to the API for mutual TLS. Requires `client_key`.",
+				MarkdownDescription: "PEM-encoded client certificate presented to the API for mutual TLS. Requires `client_key`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Description:         "PEM-encoded private key of `client_cert`.",
+				MarkdownDescription: "PEM-encoded private key of `client_cert`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description:         "Skip verification of the API TLS certificate. Only use this for development, never in production.",
+				MarkdownDescription: "Skip verification of the API TLS certificate. Only use this for development, never in production.",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Description:         "Text appended to the User-Agent of every API call, e.g. a CI job identifier. If not set, Streamkap will use environment variable `STREAMKAP_USER_AGENT_SUFFIX`",
+				MarkdownDescription: "Text appended to the `User-Agent` of every API call, e.g. a CI job identifier. If not set, Streamkap will use environment variable `STREAMKAP_USER_AGENT_SUFFIX`",
+				Optional:            true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				Description:         fmt.Sprintf("Timeout in seconds of a single API call attempt. Defaults to %d.", int64(api.DefaultRequestTimeout/time.Second)),
+				MarkdownDescription: fmt.Sprintf("Timeout in seconds of a single API call attempt. Defaults to `%d`.", int64(api.DefaultRequestTimeout/time.Second)),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -121,6 +261,24 @@ func (p *streamkapProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.Token.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Unknown Streamkap API token",
+			"The provider cannot create the Streamkap API client as there is an unknown configuration value for the Streamkap API token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the STREAMKAP_TOKEN environment variable.",
+		)
+	}
+
+	if config.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown Streamkap API credentials_file",
+			"The provider cannot create the Streamkap API client as there is an unknown configuration value for the Streamkap API credentials_file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the STREAMKAP_CREDENTIALS_FILE environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -131,6 +289,8 @@ func (p *streamkapProvider) Configure(ctx context.Context, req provider.Configur
 	host := os.Getenv("STREAMKAP_HOST")
 	clientID := os.Getenv("STREAMKAP_CLIENT_ID")
 	secret := os.Getenv("STREAMKAP_SECRET")
+	token := os.Getenv("STREAMKAP_TOKEN")
+	credentialsFile := os.Getenv("STREAMKAP_CREDENTIALS_FILE")
 
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
@@ -144,28 +304,56 @@ func (p *streamkapProvider) Configure(ctx context.Context, req provider.Configur
 		secret = config.Secret.ValueString()
 	}
 
+	if !config.Token.IsNull() {
+		token = config.Token.ValueString()
+	}
+
+	if !config.CredentialsFile.IsNull() {
+		credentialsFile = config.CredentialsFile.ValueString()
+	}
+
+	// The credentials file only fills in what is not set otherwise.
+	if token == "" && credentialsFile != "" && (clientID == "" || secret == "") {
+		creds, err := api.ReadCredentialsFile(credentialsFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_file"),
+				"Invalid Streamkap API credentials_file",
+				"The provider cannot read the Streamkap API credentials file.\n\n"+
+					"Streamkap Client Error: "+err.Error(),
+			)
+			return
+		}
+		if clientID == "" {
+			clientID = creds.ClientID
+		}
+		if secret == "" {
+			secret = creds.Secret
+		}
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 	if host == "" {
 		host = "https://api.streamkap.com"
 	}
 
-	if clientID == "" {
+	if clientID == "" && token == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("client_id"),
 			"Missing Streamkap API client_id",
 			"The provider cannot create the Streamkap API client as there is a missing or empty value for the Streamkap API client_id. "+
-				"Set the client_id value in the configuration or use the STREAMKAP_CLIENT_ID environment variable. "+
+				"Set the client_id value in the configuration, use the STREAMKAP_CLIENT_ID environment variable or a credentials_file, or set a pre-issued token. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if secret == "" {
+	if secret == "" && token == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("secret"),
 			"Missing Streamkap API secret",
 			"The provider cannot create the Streamkap API client as there is a missing or empty value for the Streamkap API secret. "+
-				"Set the secret value in the configuration or use the STREAMKAP_SECRET environment variable. "+
+				"Set the secret value in the configuration, use the STREAMKAP_SECRET environment variable or a credentials_file, or set a pre-issued token. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -174,11 +362,108 @@ func (p *streamkapProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	p.client = api.NewClient(&api.Config{
-		BaseURL: host,
+	maxRetries := api.DefaultMaxRetries
+	retryWaitMin := api.DefaultRetryWaitMin
+	retryWaitMax := api.DefaultRetryWaitMax
+
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if !config.RetryWaitMin.IsNull() {
+		retryWaitMin = time.Duration(config.RetryWaitMin.ValueInt64()) * time.Second
+	}
+
+	if !config.RetryWaitMax.IsNull() {
+		retryWaitMax = time.Duration(config.RetryWaitMax.ValueInt64()) * time.Second
+	}
+
+	if retryWaitMax < retryWaitMin {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_max"),
+			"Invalid Streamkap API retry_wait_max",
+			fmt.Sprintf("retry_wait_max (%s) must be greater than or equal to retry_wait_min (%s).", retryWaitMax, retryWaitMin),
+		)
+		return
+	}
+
+	maxRequestsPerSecond := int64(api.DefaultMaxRequestsPerSecond)
+	if !config.MaxRequestsPerSecond.IsNull() {
+		maxRequestsPerSecond = config.MaxRequestsPerSecond.ValueInt64()
+	}
+
+	maxConcurrentRequests := int64(api.DefaultMaxConcurrentRequests)
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+	}
+
+	caCertFile := os.Getenv("STREAMKAP_CA_CERT_FILE")
+	if !config.CACertFile.IsNull() {
+		caCertFile = config.CACertFile.ValueString()
+	}
+
+	tokenTimeout := api.DefaultTokenTimeout
+	if !config.TokenTimeout.IsNull() {
+		tokenTimeout = time.Duration(config.TokenTimeout.ValueInt64()) * time.Second
+	}
+
+	requestTimeout := api.DefaultRequestTimeout
+	if !config.RequestTimeout.IsNull() {
+		requestTimeout = time.Duration(config.RequestTimeout.ValueInt64()) * time.Second
+	}
+
+	if config.InsecureSkipVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Streamkap API TLS verification disabled",
+			"The provider does not verify the Streamkap API TLS certificate. Only use insecure_skip_verify for development.",
+		)
+	}
+
+	userAgentSuffix := os.Getenv("STREAMKAP_USER_AGENT_SUFFIX")
+	if !config.UserAgentSuffix.IsNull() {
+		userAgentSuffix = config.UserAgentSuffix.ValueString()
+	}
+
+	client, err := api.NewClient(&api.Config{
+		BaseURL:               host,
+		MaxRetries:            maxRetries,
+		RetryWaitMin:          retryWaitMin,
+		RetryWaitMax:          retryWaitMax,
+		MaxRequestsPerSecond:  float64(maxRequestsPerSecond),
+		MaxConcurrentRequests: int(maxConcurrentRequests),
+		HTTPProxy:             config.HTTPProxy.ValueString(),
+		CACertFile:            caCertFile,
+		CACertPEM:             config.CACertPEM.ValueString(),
+		ClientCert:            config.ClientCert.ValueString(),
+		ClientKey:             config.ClientKey.ValueString(),
+		InsecureSkipVerify:    config.InsecureSkipVerify.ValueBool(),
+		RequestTimeout:        requestTimeout,
+		TokenTimeout:          tokenTimeout,
+		UserAgent:             api.UserAgent(p.version, req.TerraformVersion, userAgentSuffix),
+		ClientInfo:            api.ClientInfo(p.version, req.TerraformVersion),
 	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Streamkap API HTTP configuration",
+			"The provider cannot create the Streamkap API client as its HTTP configuration is invalid.\n\n"+
+				"Streamkap Client Error: "+err.Error(),
+		)
+		return
+	}
+	p.client = client
+
+	// A pre-issued token is used as is.
+	if token != "" {
+		p.client.SetToken(&api.Token{AccessToken: token})
+		resp.DataSourceData = p.client
+		resp.ResourceData = p.client
+		resp.EphemeralResourceData = &ephemeral.ProviderData{Client: p.client}
+		return
+	}
+
 	// Create a new Streamkap client using the configuration values
-	token, err := p.client.GetAccessToken(clientID, secret)
+	accessToken, err := p.client.GetAccessToken(ctx, clientID, secret)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Streamkap API Client",
@@ -188,12 +473,14 @@ func (p *streamkapProvider) Configure(ctx context.Context, req provider.Configur
 		)
 		return
 	}
-	p.client.SetToken(token)
+	p.client.SetToken(accessToken)
 
 	// Make the Streamkap client available during TokenDS and Resource
 	// type Configure methods.
 	resp.DataSourceData = p.client
 	resp.ResourceData = p.client
+	// Ephemeral resources exchange the credentials for tokens of their own.
+	resp.EphemeralResourceData = &ephemeral.ProviderData{Client: p.client, ClientID: clientID, Secret: secret}
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -201,6 +488,19 @@ func (p *streamkapProvider) DataSources(_ context.Context) []func() datasource.D
 	return []func() datasource.DataSource{
 		ds.NewTransformDataSource,
 		ds.NewTagDataSource,
+		ds.NewSourcesDataSource,
+		ds.NewDestinationsDataSource,
+		ds.NewPipelinesDataSource,
+		ds.NewSourceDataSource,
+		ds.NewDestinationDataSource,
+		ds.NewSourceTopicsDataSource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *streamkapProvider) EphemeralResources(_ context.Context) []func() eph.EphemeralResource {
+	return []func() eph.EphemeralResource{
+		ephemeral.NewAccessTokenEphemeralResource,
 	}
 }
 
@@ -222,5 +522,8 @@ func (p *streamkapProvider) Resources(_ context.Context) []func() resource.Resou
 		destination.NewDestinationKafkaResource,
 		pipeline.NewPipelineResource,
 		topic.NewTopicResource,
+		tag.NewTagResource,
+		transform.NewTransformResource,
+		snapshot.NewSourceSnapshotResource,
 	}
 }
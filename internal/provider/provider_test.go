@@ -1,11 +1,16 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/fakeapi"
 )
 
 const (
@@ -34,3 +39,153 @@ func TestAccPreCheck(t *testing.T) {
 		t.Fatal("STREAMKAP_SECRET must be set for acceptance tests")
 	}
 }
+
+// TestResourceSchemas builds the schema of every resource, which panics on an
+// immutable attribute the resource does not have.
+func TestResourceSchemas(t *testing.T) {
+	ctx := context.Background()
+	for _, newResource := range New("test")().Resources(ctx) {
+		r := newResource()
+		metadata := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "streamkap"}, metadata)
+		t.Run(metadata.TypeName, func(t *testing.T) {
+			resp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, resp)
+			resp.Diagnostics.Append(resp.Schema.ValidateImplementation(ctx)...)
+			if resp.Diagnostics.HasError() {
+				t.Errorf("unexpected schema errors: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// useFakeAPI is true when STREAMKAP_FAKE_API=1, or when no Streamkap
+// credentials are set: the acceptance tests then run against an in-memory
+// fake of the Streamkap API, see TestMain.
+var useFakeAPI = os.Getenv("STREAMKAP_FAKE_API") == "1" ||
+	(os.Getenv("STREAMKAP_CLIENT_ID") == "" && os.Getenv("STREAMKAP_SECRET") == "" &&
+		os.Getenv("STREAMKAP_TOKEN") == "" && os.Getenv("STREAMKAP_CREDENTIALS_FILE") == "")
+
+// testAccVars are the Terraform variables of the acceptance test configs.
+var testAccVars = []string{
+	"destination_clickhouse_connection_password",
+	"destination_clickhouse_connection_username",
+	"destination_clickhouse_hostname",
+	"destination_databricks_connection_url",
+	"destination_databricks_token",
+	"destination_postgresql_hostname",
+	"destination_postgresql_password",
+	"destination_snowflake_key_passphrase",
+	"destination_snowflake_private_key",
+	"destination_snowflake_private_key_nocrypt",
+	"destination_snowflake_url_name",
+	"iceberg_aws_access_key",
+	"iceberg_aws_secret_key",
+	"s3_aws_access_key",
+	"s3_aws_secret_key",
+	"source_dynamodb_aws_access_key_id",
+	"source_dynamodb_aws_region",
+	"source_dynamodb_aws_secret_key",
+	"source_mongodb_connection_string",
+	"source_mongodb_ssh_host",
+	"source_mysql_hostname",
+	"source_mysql_password",
+	"source_mysql_ssh_host",
+	"source_postgresql_hostname",
+	"source_postgresql_password",
+	"source_postgresql_ssh_host",
+	"source_sqlserver_hostname",
+	"source_sqlserver_password",
+	"source_sqlserver_ssh_host",
+}
+
+// testAccVar returns the value of the Terraform variable name, taken from
+// TF_VAR_<name>. Against the fake API, unset variables get a placeholder.
+func testAccVar(name string) string {
+	value := os.Getenv("TF_VAR_" + name)
+	if value == "" && useFakeAPI {
+		value = "fake-" + name
+		os.Setenv("TF_VAR_"+name, value)
+	}
+	return value
+}
+
+// testAccClient returns a Streamkap API client configured like the provider,
+// e.g. to check the API from a CheckDestroy function.
+func testAccClient(ctx context.Context) (api.StreamkapAPI, error) {
+	host := os.Getenv("STREAMKAP_HOST")
+	if host == "" {
+		host = "https://api.streamkap.com"
+	}
+	client, err := api.NewClient(&api.Config{BaseURL: host})
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv("STREAMKAP_TOKEN"); token != "" {
+		client.SetToken(&api.Token{AccessToken: token})
+		return client, nil
+	}
+	token, err := client.GetAccessToken(ctx, os.Getenv("STREAMKAP_CLIENT_ID"), os.Getenv("STREAMKAP_SECRET"))
+	if err != nil {
+		return nil, err
+	}
+	client.SetToken(token)
+	return client, nil
+}
+
+func TestMain(m *testing.M) {
+	if !useFakeAPI {
+		os.Exit(m.Run())
+	}
+
+	if os.Getenv("STREAMKAP_FAKE_API") != "1" {
+		fmt.Fprintln(os.Stderr, "No Streamkap credentials are set, running the acceptance tests against the fake Streamkap API")
+	}
+	server := fakeapi.NewServer()
+	seedFakeAPI(server)
+	os.Setenv("STREAMKAP_HOST", server.URL)
+	os.Setenv("STREAMKAP_CLIENT_ID", fakeapi.ClientID)
+	os.Setenv("STREAMKAP_SECRET", fakeapi.Secret)
+	for _, name := range testAccVars {
+		testAccVar(name)
+	}
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
+// seedFakeAPI adds the objects the acceptance tests expect to exist in the
+// Streamkap test account.
+func seedFakeAPI(server *fakeapi.Server) {
+	server.AddTransform(api.Transform{
+		ID:       "67d43b4ed21e8f093edae34b",
+		Name:     "test-transform",
+		TopicIDs: []string{"transform_67d43b4ed21e8f093edae34b.public.test_transformed"},
+		Topics:   []string{"public.test_transformed"},
+	})
+	server.AddTransform(api.Transform{
+		ID:       "67dbe945308e0871a4e1fc49",
+		Name:     "another-test-transform",
+		TopicIDs: []string{"transform_67dbe945308e0871a4e1fc49.test"},
+		Topics:   []string{"test"},
+	})
+	server.AddTag(api.Tag{
+		ID:     "670e5ca40afe1d3983ce0c22",
+		Name:   "Development",
+		Type:   []string{"pipelines"},
+		System: true,
+	})
+	server.AddTag(api.Tag{
+		ID:     "670e5bab0d119c0d1f8cda9d",
+		Name:   "Production",
+		Type:   []string{"pipelines"},
+		System: true,
+	})
+	server.AddTopic(api.Topic{
+		TopicID:        "source_67adbcc172417ef6338e01a1.default.tst-junit-2",
+		Name:           "default.tst-junit-2",
+		SourceID:       "67adbcc172417ef6338e01a1",
+		PartitionCount: 24,
+	})
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSourceDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + pipelineSrcPostgreSQLResourceDef + pipelineDestSnowflakeResourceDef + `
+data "streamkap_source" "test" {
+	name       = streamkap_source_postgresql.test.name
+	depends_on = [streamkap_source_postgresql.test]
+}
+
+data "streamkap_destination" "test" {
+	id = streamkap_destination_snowflake.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.streamkap_source.test", "id", "streamkap_source_postgresql.test", "id"),
+					resource.TestCheckResourceAttr("data.streamkap_source.test", "connector", "postgresql"),
+					resource.TestCheckResourceAttr("data.streamkap_source.test", "config.database.port.user.defined", "5432"),
+					resource.TestCheckNoResourceAttr("data.streamkap_source.test", "config.database.password"),
+					resource.TestCheckResourceAttr("data.streamkap_destination.test", "name", "test-destination-snowflake"),
+					resource.TestCheckResourceAttr("data.streamkap_destination.test", "connector", "snowflake"),
+					resource.TestCheckNoResourceAttr("data.streamkap_destination.test", "config.snowflake.private.key"),
+				),
+			},
+		},
+	})
+}
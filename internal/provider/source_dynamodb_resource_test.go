@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-var sourceDynamoDBAWSRegion = os.Getenv("TF_VAR_source_dynamodb_aws_region")
-var sourceDynamoDBAWSAcessKeyID = os.Getenv("TF_VAR_source_dynamodb_aws_access_key_id")
-var sourceDynamoDBAWSSecretKey = os.Getenv("TF_VAR_source_dynamodb_aws_secret_key")
+var sourceDynamoDBAWSRegion = testAccVar("source_dynamodb_aws_region")
+var sourceDynamoDBAWSAcessKeyID = testAccVar("source_dynamodb_aws_access_key_id")
+var sourceDynamoDBAWSSecretKey = testAccVar("source_dynamodb_aws_secret_key")
 
 func TestAccSourceDynamoDBResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -1,14 +1,13 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-var sourceMongoDBConnectionString = os.Getenv("TF_VAR_source_mongodb_connection_string")
-var sourceMongoDBSSHHost = os.Getenv("TF_VAR_source_mongodb_ssh_host")
+var sourceMongoDBConnectionString = testAccVar("source_mongodb_connection_string")
+var sourceMongoDBSSHHost = testAccVar("source_mongodb_ssh_host")
 
 func TestAccSourceMongoDBResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-var sourceMySQLHostname = os.Getenv("TF_VAR_source_mysql_hostname")
-var sourceMySQLPassword = os.Getenv("TF_VAR_source_mysql_password")
-var sourceMySQLSSHHost = os.Getenv("TF_VAR_source_mysql_ssh_host")
+var sourceMySQLHostname = testAccVar("source_mysql_hostname")
+var sourceMySQLPassword = testAccVar("source_mysql_password")
+var sourceMySQLSSHHost = testAccVar("source_mysql_ssh_host")
 
 func TestAccSourceMySQLResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -1,15 +1,18 @@
 package provider
 
 import (
-	"os"
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
-var sourcePostgreSQLHostname = os.Getenv("TF_VAR_source_postgresql_hostname")
-var sourcePostgreSQLPassword = os.Getenv("TF_VAR_source_postgresql_password")
-var sourcePostgreSQLSSHHost = os.Getenv("TF_VAR_source_postgresql_ssh_host")
+var sourcePostgreSQLHostname = testAccVar("source_postgresql_hostname")
+var sourcePostgreSQLPassword = testAccVar("source_postgresql_password")
+var sourcePostgreSQLSSHHost = testAccVar("source_postgresql_ssh_host")
 
 func TestAccSourcePostgreSQLResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -77,6 +80,13 @@ resource "streamkap_source_postgresql" "test" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// Step 2b: ImportState by name testing
+			{
+				ResourceName:      "streamkap_source_postgresql.test",
+				ImportState:       true,
+				ImportStateId:     "name:test-source-postgresql",
+				ImportStateVerify: true,
+			},
 			// Step 3: Update and Read testing
 			{
 				Config: providerConfig + `
@@ -258,3 +268,140 @@ resource "streamkap_source_postgresql" "test" {
 		},
 	})
 }
+
+func testAccSourcePostgreSQLOptionsConfig(options string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "streamkap_source_postgresql" "test" {
+	name                 = "test-source-postgresql-options"
+	database_hostname    = "postgresql.example.com"
+	database_user        = "postgresql"
+	database_password    = "secret"
+	database_dbname      = "postgres"
+	schema_include_list  = "streamkap"
+	table_include_list   = "streamkap.customer"
+	%s
+}
+`, options)
+}
+
+func TestAccSourcePostgreSQLResourceConfigValidators(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourcePostgreSQLOptionsConfig(`
+	column_include_list = "streamkap[.]customer[.](id|name)"
+	column_exclude_list = "streamkap.customer.name"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config:      testAccSourcePostgreSQLOptionsConfig(`ssh_enabled = true`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`ssh_host\s+must\s+be\s+set\s+when\s+ssh_enabled`),
+			},
+			{
+				Config:      testAccSourcePostgreSQLOptionsConfig(`heartbeat_data_collection_schema_or_database = "streamkap"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`heartbeat_data_collection_schema_or_database\s+can\s+only\s+be\s+set`),
+			},
+		},
+	})
+}
+
+func TestAccSourcePostgreSQLResourceImmutable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourcePostgreSQLOptionsConfig(`snapshot_read_only = "No"`),
+			},
+			// Changing a mutable setting updates the source in place
+			{
+				Config: testAccSourcePostgreSQLOptionsConfig(`snapshot_read_only = "Yes"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("streamkap_source_postgresql.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Changing the replication slot replaces it
+			{
+				Config: testAccSourcePostgreSQLOptionsConfig(`
+	snapshot_read_only = "Yes"
+	slot_name          = "terraform_pgoutput_slot"
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("streamkap_source_postgresql.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("streamkap_source_postgresql.test", "slot_name", "terraform_pgoutput_slot"),
+			},
+		},
+	})
+}
+
+func testAccSourcePostgreSQLWriteOnlyConfig(password string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "streamkap_source_postgresql" "test" {
+	name                 = "test-source-postgresql-write-only"
+	database_hostname    = "postgresql.example.com"
+	database_user        = "postgresql"
+	database_dbname      = "postgres"
+	schema_include_list  = "streamkap"
+	table_include_list   = "streamkap.customer"
+	%s
+}
+`, password)
+}
+
+func TestAccSourcePostgreSQLResourceWriteOnlyPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourcePostgreSQLWriteOnlyConfig(`
+	database_password_wo         = "secret"
+	database_password_wo_version = 1
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("streamkap_source_postgresql.test", "database_password"),
+					resource.TestCheckNoResourceAttr("streamkap_source_postgresql.test", "database_password_wo"),
+					resource.TestCheckResourceAttr("streamkap_source_postgresql.test", "database_password_wo_version", "1"),
+				),
+			},
+			// A new value is only sent along with a new version
+			{
+				Config: testAccSourcePostgreSQLWriteOnlyConfig(`
+	database_password_wo         = "rotated"
+	database_password_wo_version = 2
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("streamkap_source_postgresql.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckNoResourceAttr("streamkap_source_postgresql.test", "database_password"),
+			},
+			{
+				Config: testAccSourcePostgreSQLWriteOnlyConfig(`
+	database_password            = "secret"
+	database_password_wo         = "secret"
+	database_password_wo_version = 1
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config:      testAccSourcePostgreSQLWriteOnlyConfig(`database_password_wo = "secret"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`database_password_wo_version`),
+			},
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSourceSnapshotConfig(backfill string, wait bool) string {
+	return providerConfig + pipelineSrcPostgreSQLResourceDef + fmt.Sprintf(`
+resource "streamkap_source_snapshot" "test" {
+	source_id = streamkap_source_postgresql.test.id
+	tables    = [
+		"streamkap.customer2",
+	]
+	triggers = {
+		backfill = %[1]q
+	}
+	wait_for_completion = %[2]t
+}
+`, backfill, wait)
+}
+
+func TestAccSourceSnapshotResource(t *testing.T) {
+	var firstID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Fire and wait
+			{
+				Config: testAccSourceSnapshotConfig("1", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_source_snapshot.test", "status", "COMPLETED"),
+					resource.TestCheckResourceAttr("streamkap_source_snapshot.test", "tables.#", "1"),
+					resource.TestCheckResourceAttrWith("streamkap_source_snapshot.test", "id", func(value string) error {
+						firstID = value
+						return nil
+					}),
+				),
+			},
+			// Changing the triggers fires a new snapshot
+			{
+				Config: testAccSourceSnapshotConfig("2", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("streamkap_source_snapshot.test", "id", func(value string) error {
+						if value == firstID {
+							return fmt.Errorf("expected a new snapshot, got %s again", value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrSet("streamkap_source_snapshot.test", "status"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -1,15 +1,14 @@
 package provider
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-var sourceSQLServerHostname = os.Getenv("TF_VAR_source_sqlserver_hostname")
-var sourceSQLServerPassword = os.Getenv("TF_VAR_source_sqlserver_password")
-var sourceSQLServerSSHHost = os.Getenv("TF_VAR_source_sqlserver_ssh_host")
+var sourceSQLServerHostname = testAccVar("source_sqlserver_hostname")
+var sourceSQLServerPassword = testAccVar("source_sqlserver_password")
+var sourceSQLServerSSHHost = testAccVar("source_sqlserver_ssh_host")
 
 func TestAccSourceSQLServerResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
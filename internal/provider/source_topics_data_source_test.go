@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSourceTopicsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + pipelineSrcPostgreSQLResourceDef + `
+data "streamkap_source_topics" "test" {
+	source_id  = streamkap_source_postgresql.test.id
+	name_regex = "customer2$"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.streamkap_source_topics.test", "topics.#", "1"),
+					resource.TestCheckResourceAttr("data.streamkap_source_topics.test", "topics.0", "streamkap.customer2"),
+					resource.TestCheckResourceAttr("data.streamkap_source_topics.test", "topic_map.0.name", "streamkap.customer2"),
+					resource.TestCheckResourceAttrSet("data.streamkap_source_topics.test", "topic_ids.0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSourcesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + pipelineSrcPostgreSQLResourceDef + pipelineDestSnowflakeResourceDef + `
+data "streamkap_sources" "test" {
+	connector  = "postgresql"
+	name_regex = "^test-source-postgresql$"
+	depends_on = [streamkap_source_postgresql.test]
+}
+
+data "streamkap_destinations" "test" {
+	name_regex = "^test-destination-snowflake$"
+	depends_on = [streamkap_destination_snowflake.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.streamkap_sources.test", "sources.#", "1"),
+					resource.TestCheckResourceAttrPair("data.streamkap_sources.test", "sources.0.id", "streamkap_source_postgresql.test", "id"),
+					resource.TestCheckResourceAttr("data.streamkap_sources.test", "sources.0.name", "test-source-postgresql"),
+					resource.TestCheckResourceAttr("data.streamkap_sources.test", "sources.0.connector", "postgresql"),
+					resource.TestCheckResourceAttr("data.streamkap_destinations.test", "destinations.#", "1"),
+					resource.TestCheckResourceAttrPair("data.streamkap_destinations.test", "destinations.0.id", "streamkap_destination_snowflake.test", "id"),
+					resource.TestCheckResourceAttr("data.streamkap_destinations.test", "destinations.0.connector", "snowflake"),
+				),
+			},
+		},
+	})
+}
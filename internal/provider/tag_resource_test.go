@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTagResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Step 1: Create and Read testing
+			{
+				Config: providerConfig + `
+resource "streamkap_tag" "test" {
+	name        = "tf-acc-test-tag"
+	description = "Created by the acceptance tests"
+	type        = ["pipelines"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_tag.test", "name", "tf-acc-test-tag"),
+					resource.TestCheckResourceAttr("streamkap_tag.test", "description", "Created by the acceptance tests"),
+					resource.TestCheckResourceAttr("streamkap_tag.test", "type.#", "1"),
+					resource.TestCheckResourceAttr("streamkap_tag.test", "type.0", "pipelines"),
+					resource.TestCheckResourceAttr("streamkap_tag.test", "system", "false"),
+					resource.TestCheckResourceAttrSet("streamkap_tag.test", "id"),
+				),
+			},
+			// Step 2: ImportState testing
+			{
+				ResourceName:      "streamkap_tag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Step 3: Update and Read testing
+			{
+				Config: providerConfig + `
+resource "streamkap_tag" "test" {
+	name = "tf-acc-test-tag-updated"
+	type = ["pipelines", "sources"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_tag.test", "name", "tf-acc-test-tag-updated"),
+					resource.TestCheckResourceAttr("streamkap_tag.test", "description", ""),
+					resource.TestCheckResourceAttr("streamkap_tag.test", "type.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -1,15 +1,24 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
 )
 
 
 func TestAccTopicResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The topic is produced by a source, destroying the resource keeps it.
+		CheckDestroy: testAccCheckTopicExists("source_67adbcc172417ef6338e01a1.default.tst-junit-2", true),
 		Steps: []resource.TestStep{
 			// Step 1: Create and Read testing
 			{
@@ -22,13 +31,16 @@ resource "streamkap_topic" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("streamkap_topic.test", "topic_id", "source_67adbcc172417ef6338e01a1.default.tst-junit-2"),
 					resource.TestCheckResourceAttr("streamkap_topic.test", "partition_count", "25"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "managed", "false"),
 				),
 			},
 			// Step 2: ImportState testing
 			{
-				ResourceName:      "topic_id.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:                         "streamkap_topic.test",
+				ImportState:                          true,
+				ImportStateId:                        "source_67adbcc172417ef6338e01a1.default.tst-junit-2",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "topic_id",
 			},
 			// Step 3: Update and Read testing
 			{
@@ -47,3 +59,88 @@ resource "streamkap_topic" "test" {
 		},
 	})
 }
+
+func TestAccTopicResourceLifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckTopicExists("tf-acc-test-topic", false),
+		Steps: []resource.TestStep{
+			// Step 1: Create and Read testing
+			{
+				Config: providerConfig + `
+resource "streamkap_topic" "test" {
+	topic_id           = "tf-acc-test-topic"
+	partition_count    = 3
+	replication_factor = 3
+	retention_ms       = 86400000
+	cleanup_policy     = "delete"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_topic.test", "topic_id", "tf-acc-test-topic"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "partition_count", "3"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "replication_factor", "3"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "retention_ms", "86400000"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "cleanup_policy", "delete"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "managed", "true"),
+				),
+			},
+			// Step 2: Update and Read testing
+			{
+				Config: providerConfig + `
+resource "streamkap_topic" "test" {
+	topic_id              = "tf-acc-test-topic"
+	partition_count       = 6
+	replication_factor    = 3
+	retention_ms          = -1
+	cleanup_policy        = "compact"
+	min_compaction_lag_ms = 60000
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_topic.test", "partition_count", "6"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "retention_ms", "-1"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "cleanup_policy", "compact"),
+					resource.TestCheckResourceAttr("streamkap_topic.test", "min_compaction_lag_ms", "60000"),
+				),
+			},
+			// Step 3: Shrinking the partitions fails at plan time
+			{
+				Config: providerConfig + `
+resource "streamkap_topic" "test" {
+	topic_id           = "tf-acc-test-topic"
+	partition_count    = 2
+	replication_factor = 3
+	retention_ms       = -1
+	cleanup_policy     = "compact"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Cannot decrease partition count"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckTopicExists checks that the topic exists, or not, once the
+// resources are destroyed.
+func testAccCheckTopicExists(topicID string, exists bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		ctx := context.Background()
+		client, err := testAccClient(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetTopic(ctx, topicID)
+		switch {
+		case exists && err != nil:
+			return fmt.Errorf("expected topic %s to survive destroy, got: %w", topicID, err)
+		case !exists && err == nil:
+			return fmt.Errorf("expected topic %s to be deleted", topicID)
+		case !exists && !errors.Is(err, api.ErrNotFound):
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTransformResource(t *testing.T) {
+	codeFile := filepath.Join(t.TempDir(), "transform.js")
+	if err := os.WriteFile(codeFile, []byte("function _streamkap_transform(obj) { return obj; }\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := providerConfig + fmt.Sprintf(`
+resource "streamkap_transform" "test" {
+	name                = "tf-acc-test-transform"
+	transform_type      = "map_filter"
+	language            = "JavaScript"
+	code_file           = %q
+	input_topic_pattern = "public\\.users"
+}
+`, codeFile)
+
+	var transformID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Step 1: Create and Read testing
+			{
+				Config: providerConfig + `
+resource "streamkap_transform" "test" {
+	name                = "tf-acc-test-transform"
+	transform_type      = "map_filter"
+	language            = "JavaScript"
+	code                = "function _streamkap_transform(obj) { return obj; }"
+	input_topic_pattern = "public\\.users"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_transform.test", "name", "tf-acc-test-transform"),
+					resource.TestCheckResourceAttr("streamkap_transform.test", "transform_type", "map_filter"),
+					resource.TestCheckResourceAttr("streamkap_transform.test", "input_topic_pattern", "public\\.users"),
+					resource.TestCheckResourceAttr("streamkap_transform.test", "code_sha256", testAccSHA256("function _streamkap_transform(obj) { return obj; }")),
+					resource.TestCheckResourceAttrSet("streamkap_transform.test", "id"),
+					resource.TestCheckResourceAttrSet("streamkap_transform.test", "start_time"),
+				),
+			},
+			// Step 2: ImportState testing
+			{
+				ResourceName:      "streamkap_transform.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Step 3: Switch to a code file
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("streamkap_transform.test", "id", func(id string) error {
+						transformID = id
+						return nil
+					}),
+					resource.TestCheckResourceAttr("streamkap_transform.test", "code_file", codeFile),
+					resource.TestCheckNoResourceAttr("streamkap_transform.test", "code"),
+					resource.TestCheckResourceAttr("streamkap_transform.test", "code_sha256", testAccSHA256("function _streamkap_transform(obj) { return obj; }\n")),
+				),
+			},
+			// Step 4: Editing the file plans an update
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(codeFile, []byte("function _streamkap_transform(obj) { obj.email = null; return obj; }\n"), 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_transform.test", "code_sha256", testAccSHA256("function _streamkap_transform(obj) { obj.email = null; return obj; }\n")),
+				),
+			},
+			// Step 5: Code changed outside of Terraform shows up as drift
+			{
+				PreConfig: func() {
+					testAccUpdateTransformCode(t, transformID, "function _streamkap_transform(obj) { return null; }")
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Step 6: Applying restores the code
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("streamkap_transform.test", "code_sha256", testAccSHA256("function _streamkap_transform(obj) { obj.email = null; return obj; }\n")),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// testAccUpdateTransformCode replaces the code of a transform through the
+// API, as an edit in the Streamkap UI would.
+func testAccUpdateTransformCode(t *testing.T, transformID, code string) {
+	ctx := context.Background()
+	client, err := testAccClient(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transform, err := client.GetTransform(ctx, transformID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transform.Code = code
+	if _, err := client.UpdateTransform(ctx, transformID, *transform); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,9 +3,11 @@ package destination
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -43,21 +45,25 @@ type DestinationClickHouseResource struct {
 
 // DestinationClickHouseResourceModel describes the resource data model.
 type DestinationClickHouseResourceModel struct {
-	ID                 types.String                                  `tfsdk:"id"`
-	Name               types.String                                  `tfsdk:"name"`
-	Connector          types.String                                  `tfsdk:"connector"`
-	IngestionMode      types.String                                  `tfsdk:"ingestion_mode"`
-	HardDelete         types.Bool                                    `tfsdk:"hard_delete"`
-	TasksMax           types.Int64                                   `tfsdk:"tasks_max"`
-	Hostname           types.String                                  `tfsdk:"hostname"`
-	ConnectionUsername types.String                                  `tfsdk:"connection_username"`
-	ConnectionPassword types.String                                  `tfsdk:"connection_password"`
-	Port               types.Int64                                   `tfsdk:"port"`
-	Database           types.String                                  `tfsdk:"database"`
-	SSL                types.Bool                                    `tfsdk:"ssl"`
-	TopicsConfigMap    map[string]clickHouseTopicsConfigMapItemModel `tfsdk:"topics_config_map"`
-	SchemaEvolution    types.String                                  `tfsdk:"schema_evolution"`
-	QuoteIdentifiers   types.Bool                                    `tfsdk:"quote_identifiers"`
+	ID                          types.String                                  `tfsdk:"id"`
+	Name                        types.String                                  `tfsdk:"name"`
+	Connector                   types.String                                  `tfsdk:"connector"`
+	IngestionMode               types.String                                  `tfsdk:"ingestion_mode"`
+	HardDelete                  types.Bool                                    `tfsdk:"hard_delete"`
+	TasksMax                    types.Int64                                   `tfsdk:"tasks_max"`
+	Hostname                    types.String                                  `tfsdk:"hostname"`
+	ConnectionUsername          types.String                                  `tfsdk:"connection_username"`
+	ConnectionPassword          types.String                                  `tfsdk:"connection_password"`
+	ConnectionPasswordWO        types.String                                  `tfsdk:"connection_password_wo"`
+	ConnectionPasswordWOVersion types.Int64                                   `tfsdk:"connection_password_wo_version"`
+	Port                        types.Int64                                   `tfsdk:"port"`
+	Database                    types.String                                  `tfsdk:"database"`
+	SSL                         types.Bool                                    `tfsdk:"ssl"`
+	TopicsConfigMap             map[string]clickHouseTopicsConfigMapItemModel `tfsdk:"topics_config_map"`
+	SchemaEvolution             types.String                                  `tfsdk:"schema_evolution"`
+	QuoteIdentifiers            types.Bool                                    `tfsdk:"quote_identifiers"`
+	WaitForRunning              types.Bool                                    `tfsdk:"wait_for_running"`
+	Timeouts                    timeouts.Value                                `tfsdk:"timeouts"`
 }
 
 type clickHouseTopicsConfigMapItemModel struct {
@@ -133,11 +139,16 @@ func (r *DestinationClickHouseResource) Schema(ctx context.Context, req res.Sche
 				MarkdownDescription: "Username to access ClickHouse",
 			},
 			"connection_password": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "Password to access the ClickHouse",
-				MarkdownDescription: "Password to access the ClickHouse",
+				Description:         "Password to access the ClickHouse. Exactly one of connection_password or connection_password_wo must be set.",
+				MarkdownDescription: "Password to access the ClickHouse. Exactly one of `connection_password` or `connection_password_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("connection_password_wo")),
+				},
 			},
+			"connection_password_wo":         helper.WriteOnlyAttribute("connection_password"),
+			"connection_password_wo_version": helper.WriteOnlyVersionAttribute("connection_password"),
 			"port": schema.Int64Attribute{
 				Computed:            true,
 				Optional:            true,
@@ -189,8 +200,14 @@ func (r *DestinationClickHouseResource) Schema(ctx context.Context, req res.Sche
 				Description:         "Whether to quote identifiers in SQL statements",
 				MarkdownDescription: "Whether to quote identifiers in SQL statements",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableDestinationAttributes[r.connector_code])
 }
 
 func (r *DestinationClickHouseResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -216,12 +233,24 @@ func (r *DestinationClickHouseResource) Create(ctx context.Context, req res.Crea
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connection_password_wo"), &plan.ConnectionPasswordWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Pre CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config, err := r.model2ConfigMap(plan)
 	if err != nil {
@@ -253,6 +282,17 @@ func (r *DestinationClickHouseResource) Create(ctx context.Context, req res.Crea
 	r.configMap2Model(destination.Config, &plan)
 	tflog.Debug(ctx, "Post CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	// A destination that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating ClickHouse destination",
+				fmt.Sprintf("Unable to create ClickHouse destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -265,6 +305,8 @@ func (r *DestinationClickHouseResource) Read(ctx context.Context, req res.ReadRe
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -272,6 +314,10 @@ func (r *DestinationClickHouseResource) Read(ctx context.Context, req res.ReadRe
 
 	destinationID := state.ID.ValueString()
 	destination, err := r.client.GetDestination(ctx, destinationID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading ClickHouse destination",
@@ -279,16 +325,17 @@ func (r *DestinationClickHouseResource) Read(ctx context.Context, req res.ReadRe
 		)
 		return
 	}
-	if destination == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(destination.Name)
 	state.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &state)
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", state))
 
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -301,10 +348,22 @@ func (r *DestinationClickHouseResource) Update(ctx context.Context, req res.Upda
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connection_password_wo"), &plan.ConnectionPasswordWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	config, err := r.model2ConfigMap(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -332,6 +391,16 @@ func (r *DestinationClickHouseResource) Update(ctx context.Context, req res.Upda
 	plan.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &plan)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating ClickHouse destination",
+				fmt.Sprintf("Unable to update ClickHouse destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -344,9 +413,19 @@ func (r *DestinationClickHouseResource) Delete(ctx context.Context, req res.Dele
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	err := r.client.DeleteDestination(ctx, state.ID.ValueString())
 	if err != nil {
@@ -359,7 +438,7 @@ func (r *DestinationClickHouseResource) Delete(ctx context.Context, req res.Dele
 }
 
 func (r *DestinationClickHouseResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportDestinationState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -404,7 +483,7 @@ func (r *DestinationClickHouseResource) model2ConfigMap(model DestinationClickHo
 		"tasks.max":           model.TasksMax.ValueInt64(),
 		"hostname":            model.Hostname.ValueString(),
 		"connection.username": model.ConnectionUsername.ValueString(),
-		"connection.password": model.ConnectionPassword.ValueString(),
+		"connection.password": helper.PreferWriteOnly(model.ConnectionPasswordWO, model.ConnectionPassword).ValueString(),
 		// TODO: Until API change port to int, we need to convert it to string
 		"port":              strconv.Itoa(int(model.Port.ValueInt64())),
 		"database":          model.Database.ValueStringPointer(),
@@ -422,7 +501,10 @@ func (r *DestinationClickHouseResource) configMap2Model(cfg map[string]any, mode
 	model.TasksMax = helper.GetTfCfgInt64(cfg, "tasks.max")
 	model.Hostname = helper.GetTfCfgString(cfg, "hostname")
 	model.ConnectionUsername = helper.GetTfCfgString(cfg, "connection.username")
-	model.ConnectionPassword = helper.GetTfCfgString(cfg, "connection.password")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.ConnectionPassword = helper.GetTfCfgString(cfg, "connection.password")
+	}
 	// TODO: Until API change port to int, we need to convert it to string
 	model.Port = helper.GetTfCfgInt64(cfg, "port")
 	model.Database = helper.GetTfCfgString(cfg, "database")
@@ -2,8 +2,10 @@ package destination
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -41,20 +43,24 @@ type DestinationDatabricksResource struct {
 
 // DestinationDatabricksResourceModel describes the resource data model.
 type DestinationDatabricksResourceModel struct {
-	ID                               types.String `tfsdk:"id"`
-	Name                             types.String `tfsdk:"name"`
-	Connector                        types.String `tfsdk:"connector"`
-	ConnectionUrl                    types.String `tfsdk:"connection_url"`
-	DatabricksToken                  types.String `tfsdk:"databricks_token"`
-	DatabricksCatalog                types.String `tfsdk:"databricks_catalog"`
-	TableNamePrefix                  types.String `tfsdk:"table_name_prefix"`
-	IngestionMode                    types.String `tfsdk:"ingestion_mode"`
-	PartitionMode                    types.String `tfsdk:"partition_mode"`
-	HardDelete                       types.Bool   `tfsdk:"hard_delete"`
-	SchemaEvolution                  types.String `tfsdk:"schema_evolution"`
-	TasksMax                         types.Int64  `tfsdk:"tasks_max"`
-	ConsumerWaitTimeForLargerBatchMs types.Int64  `tfsdk:"consumer_wait_time_for_larger_batch_ms"`
-	QuoteIdentifiers                 types.Bool   `tfsdk:"quote_identifiers"`
+	ID                               types.String   `tfsdk:"id"`
+	Name                             types.String   `tfsdk:"name"`
+	Connector                        types.String   `tfsdk:"connector"`
+	ConnectionUrl                    types.String   `tfsdk:"connection_url"`
+	DatabricksToken                  types.String   `tfsdk:"databricks_token"`
+	DatabricksTokenWO                types.String   `tfsdk:"databricks_token_wo"`
+	DatabricksTokenWOVersion         types.Int64    `tfsdk:"databricks_token_wo_version"`
+	DatabricksCatalog                types.String   `tfsdk:"databricks_catalog"`
+	TableNamePrefix                  types.String   `tfsdk:"table_name_prefix"`
+	IngestionMode                    types.String   `tfsdk:"ingestion_mode"`
+	PartitionMode                    types.String   `tfsdk:"partition_mode"`
+	HardDelete                       types.Bool     `tfsdk:"hard_delete"`
+	SchemaEvolution                  types.String   `tfsdk:"schema_evolution"`
+	TasksMax                         types.Int64    `tfsdk:"tasks_max"`
+	ConsumerWaitTimeForLargerBatchMs types.Int64    `tfsdk:"consumer_wait_time_for_larger_batch_ms"`
+	QuoteIdentifiers                 types.Bool     `tfsdk:"quote_identifiers"`
+	WaitForRunning                   types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts                         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *DestinationDatabricksResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -91,11 +97,16 @@ func (r *DestinationDatabricksResource) Schema(ctx context.Context, req res.Sche
 				MarkdownDescription: "JDBC URL",
 			},
 			"databricks_token": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "Token",
-				MarkdownDescription: "Token",
+				Description:         "Token. Exactly one of databricks_token or databricks_token_wo must be set.",
+				MarkdownDescription: "Token. Exactly one of `databricks_token` or `databricks_token_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("databricks_token_wo")),
+				},
 			},
+			"databricks_token_wo":         helper.WriteOnlyAttribute("databricks_token"),
+			"databricks_token_wo_version": helper.WriteOnlyVersionAttribute("databricks_token"),
 			"databricks_catalog": schema.StringAttribute{
 				Computed:            true,
 				Optional:            true,
@@ -182,8 +193,14 @@ func (r *DestinationDatabricksResource) Schema(ctx context.Context, req res.Sche
 				Description:         "Whether to quote identifiers in SQL statements",
 				MarkdownDescription: "Whether to quote identifiers in SQL statements",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableDestinationAttributes[r.connector_code])
 }
 
 func (r *DestinationDatabricksResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -209,12 +226,24 @@ func (r *DestinationDatabricksResource) Create(ctx context.Context, req res.Crea
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("databricks_token_wo"), &plan.DatabricksTokenWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Pre CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(ctx, plan)
 
@@ -240,6 +269,17 @@ func (r *DestinationDatabricksResource) Create(ctx context.Context, req res.Crea
 	r.configMap2Model(ctx, destination.Config, &plan)
 	tflog.Debug(ctx, "Post CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	// A destination that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating Databricks destination",
+				fmt.Sprintf("Unable to create Databricks destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -252,6 +292,8 @@ func (r *DestinationDatabricksResource) Read(ctx context.Context, req res.ReadRe
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -259,6 +301,10 @@ func (r *DestinationDatabricksResource) Read(ctx context.Context, req res.ReadRe
 
 	destinationID := state.ID.ValueString()
 	destination, err := r.client.GetDestination(ctx, destinationID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Databricks destination",
@@ -266,15 +312,16 @@ func (r *DestinationDatabricksResource) Read(ctx context.Context, req res.ReadRe
 		)
 		return
 	}
-	if destination == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(destination.Name)
 	state.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(ctx, destination.Config, &state)
 
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -287,9 +334,21 @@ func (r *DestinationDatabricksResource) Update(ctx context.Context, req res.Upda
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("databricks_token_wo"), &plan.DatabricksTokenWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	tflog.Debug(ctx, "Pre UPDATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(ctx, plan)
@@ -316,6 +375,16 @@ func (r *DestinationDatabricksResource) Update(ctx context.Context, req res.Upda
 	r.configMap2Model(ctx, destination.Config, &plan)
 	tflog.Debug(ctx, "Post UPDATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Databricks destination",
+				fmt.Sprintf("Unable to update Databricks destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -328,10 +397,20 @@ func (r *DestinationDatabricksResource) Delete(ctx context.Context, req res.Dele
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeleteDestination(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -343,7 +422,7 @@ func (r *DestinationDatabricksResource) Delete(ctx context.Context, req res.Dele
 }
 
 func (r *DestinationDatabricksResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportDestinationState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -351,7 +430,7 @@ func (r *DestinationDatabricksResource) model2ConfigMap(_ context.Context, model
 
 	configMap := map[string]any{
 		"connection.url.user.defined":            model.ConnectionUrl.ValueString(),
-		"databricks.token":                       model.DatabricksToken.ValueString(),
+		"databricks.token":                       helper.PreferWriteOnly(model.DatabricksTokenWO, model.DatabricksToken).ValueString(),
 		"databricks.catalog.user.defined":        model.DatabricksCatalog.ValueString(),
 		"table.name.prefix":                      model.TableNamePrefix.ValueString(),
 		"ingestion.mode":                         model.IngestionMode.ValueString(),
@@ -369,7 +448,10 @@ func (r *DestinationDatabricksResource) model2ConfigMap(_ context.Context, model
 func (r *DestinationDatabricksResource) configMap2Model(ctx context.Context, cfg map[string]any, model *DestinationDatabricksResourceModel) {
 	// Copy the config map to the model
 	model.ConnectionUrl = helper.GetTfCfgString(cfg, "connection.url.user.defined")
-	model.DatabricksToken = helper.GetTfCfgString(cfg, "databricks.token")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.DatabricksToken = helper.GetTfCfgString(cfg, "databricks.token")
+	}
 	model.DatabricksCatalog = helper.GetTfCfgString(cfg, "databricks.catalog.user.defined")
 	model.TableNamePrefix = helper.GetTfCfgString(cfg, "table.name.prefix")
 	model.IngestionMode = helper.GetTfCfgString(cfg, "ingestion.mode")
@@ -2,8 +2,10 @@ package destination
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
@@ -39,21 +41,25 @@ type DestinationIcebergResource struct {
 
 // DestinationIcebergResourceModel describes the resource data model.
 type DestinationIcebergResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	Connector        types.String `tfsdk:"connector"`
-	CatalogType      types.String `tfsdk:"catalog_type"`
-	CatalogName      types.String `tfsdk:"catalog_name"`
-	CatalogURI       types.String `tfsdk:"catalog_uri"`
-	AWSAccessKeyID   types.String `tfsdk:"aws_access_key"`
-	AWSSecretKeyID   types.String `tfsdk:"aws_secret_key"`
-	IAMRole          types.String `tfsdk:"aws_iam_role"`
-	Region           types.String `tfsdk:"aws_region"`
-	BucketPath       types.String `tfsdk:"bucket_path"`
-	Schema           types.String `tfsdk:"schema"`
-	InsertMode       types.String `tfsdk:"insert_mode"`
-	PrimaryKeyFields types.String `tfsdk:"primary_key_fields"`
-	QuoteIdentifiers types.Bool   `tfsdk:"quote_identifiers"`
+	ID                      types.String   `tfsdk:"id"`
+	Name                    types.String   `tfsdk:"name"`
+	Connector               types.String   `tfsdk:"connector"`
+	CatalogType             types.String   `tfsdk:"catalog_type"`
+	CatalogName             types.String   `tfsdk:"catalog_name"`
+	CatalogURI              types.String   `tfsdk:"catalog_uri"`
+	AWSAccessKeyID          types.String   `tfsdk:"aws_access_key"`
+	AWSSecretKeyID          types.String   `tfsdk:"aws_secret_key"`
+	AWSSecretKeyIDWO        types.String   `tfsdk:"aws_secret_key_wo"`
+	AWSSecretKeyIDWOVersion types.Int64    `tfsdk:"aws_secret_key_wo_version"`
+	IAMRole                 types.String   `tfsdk:"aws_iam_role"`
+	Region                  types.String   `tfsdk:"aws_region"`
+	BucketPath              types.String   `tfsdk:"bucket_path"`
+	Schema                  types.String   `tfsdk:"schema"`
+	InsertMode              types.String   `tfsdk:"insert_mode"`
+	PrimaryKeyFields        types.String   `tfsdk:"primary_key_fields"`
+	QuoteIdentifiers        types.Bool     `tfsdk:"quote_identifiers"`
+	WaitForRunning          types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *DestinationIcebergResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -88,8 +94,8 @@ func (r *DestinationIcebergResource) Schema(ctx context.Context, req res.SchemaR
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("rest"),
-				Description:         "Type of Iceberg catalog.",
-				MarkdownDescription: "Type of Iceberg catalog.",
+				Description:         "Type of Iceberg catalog. Changing it forces a new resource to be created.",
+				MarkdownDescription: "Type of Iceberg catalog. Changing it forces a new resource to be created.",
 				Validators: []validator.String{
 					stringvalidator.OneOf(
 						"rest", "hive", "glue",
@@ -114,9 +120,14 @@ func (r *DestinationIcebergResource) Schema(ctx context.Context, req res.SchemaR
 			"aws_secret_key": schema.StringAttribute{
 				Optional:            true,
 				Sensitive:           true,
-				Description:         "The AWS Secret Access Key used to connect to Iceberg. Required for rest and hive.",
-				MarkdownDescription: "The AWS Secret Access Key used to connect to Iceberg. Required for rest and hive.",
+				Description:         "The AWS Secret Access Key used to connect to Iceberg. Required for rest and hive. Conflicts with aws_secret_key_wo.",
+				MarkdownDescription: "The AWS Secret Access Key used to connect to Iceberg. Required for rest and hive. Conflicts with `aws_secret_key_wo`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("aws_secret_key_wo")),
+				},
 			},
+			"aws_secret_key_wo":         helper.WriteOnlyAttribute("aws_secret_key"),
+			"aws_secret_key_wo_version": helper.WriteOnlyVersionAttribute("aws_secret_key"),
 			"aws_iam_role": schema.StringAttribute{
 				Optional:            true,
 				Description:         "AWS IAM role (e.g., arn:aws:iam:::role/). Required for glue.",
@@ -186,8 +197,14 @@ func (r *DestinationIcebergResource) Schema(ctx context.Context, req res.SchemaR
 				Description:         "Whether to quote identifiers in SQL statements",
 				MarkdownDescription: "Whether to quote identifiers in SQL statements",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableDestinationAttributes[r.connector_code])
 }
 
 func (r *DestinationIcebergResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -213,12 +230,24 @@ func (r *DestinationIcebergResource) Create(ctx context.Context, req res.CreateR
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("aws_secret_key_wo"), &plan.AWSSecretKeyIDWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Pre CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(plan)
 
@@ -243,6 +272,17 @@ func (r *DestinationIcebergResource) Create(ctx context.Context, req res.CreateR
 	r.configMap2Model(destination.Config, &plan, ctx)
 	tflog.Debug(ctx, "Post CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	// A destination that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating Iceberg destination",
+				fmt.Sprintf("Unable to create Iceberg destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -255,6 +295,8 @@ func (r *DestinationIcebergResource) Read(ctx context.Context, req res.ReadReque
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -262,6 +304,10 @@ func (r *DestinationIcebergResource) Read(ctx context.Context, req res.ReadReque
 
 	destinationID := state.ID.ValueString()
 	destination, err := r.client.GetDestination(ctx, destinationID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Iceberg destination",
@@ -269,16 +315,17 @@ func (r *DestinationIcebergResource) Read(ctx context.Context, req res.ReadReque
 		)
 		return
 	}
-	if destination == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(destination.Name)
 	state.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &state, ctx)
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", state))
 
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -291,9 +338,21 @@ func (r *DestinationIcebergResource) Update(ctx context.Context, req res.UpdateR
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("aws_secret_key_wo"), &plan.AWSSecretKeyIDWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	config := r.model2ConfigMap(plan)
 
@@ -315,6 +374,16 @@ func (r *DestinationIcebergResource) Update(ctx context.Context, req res.UpdateR
 	plan.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &plan, ctx)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Iceberg destination",
+				fmt.Sprintf("Unable to update Iceberg destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -327,10 +396,20 @@ func (r *DestinationIcebergResource) Delete(ctx context.Context, req res.DeleteR
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeleteDestination(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -342,7 +421,7 @@ func (r *DestinationIcebergResource) Delete(ctx context.Context, req res.DeleteR
 }
 
 func (r *DestinationIcebergResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportDestinationState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -352,7 +431,7 @@ func (r *DestinationIcebergResource) model2ConfigMap(model DestinationIcebergRes
 		"iceberg.catalog.name":                       model.CatalogName.ValueString(),
 		"iceberg.catalog.uri":                        model.CatalogURI.ValueString(),
 		"iceberg.catalog.s3.access-key-id":           model.AWSAccessKeyID.ValueString(),
-		"iceberg.catalog.s3.secret-access-key":       model.AWSSecretKeyID.ValueString(),
+		"iceberg.catalog.s3.secret-access-key":       helper.PreferWriteOnly(model.AWSSecretKeyIDWO, model.AWSSecretKeyID).ValueString(),
 		"iceberg.catalog.client.assume-role.arn":     model.IAMRole.ValueString(),
 		"iceberg.catalog.client.region.user.defined": model.Region.ValueString(),
 		"iceberg.catalog.warehouse":                  model.BucketPath.ValueString(),
@@ -371,7 +450,10 @@ func (r *DestinationIcebergResource) configMap2Model(cfg map[string]any, model *
 	model.CatalogName = helper.GetTfCfgString(cfg, "iceberg.catalog.name")
 	model.CatalogURI = helper.GetTfCfgString(cfg, "iceberg.catalog.uri")
 	model.AWSAccessKeyID = helper.GetTfCfgString(cfg, "iceberg.catalog.s3.access-key-id")
-	model.AWSSecretKeyID = helper.GetTfCfgString(cfg, "iceberg.catalog.s3.secret-access-key")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.AWSSecretKeyID = helper.GetTfCfgString(cfg, "iceberg.catalog.s3.secret-access-key")
+	}
 	model.IAMRole = helper.GetTfCfgString(cfg, "iceberg.catalog.client.assume-role.arn")
 	model.Region = helper.GetTfCfgString(cfg, "iceberg.catalog.client.region.user.defined")
 	model.BucketPath = helper.GetTfCfgString(cfg, "iceberg.catalog.warehouse")
@@ -2,11 +2,12 @@ package destination
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -41,16 +42,18 @@ type DestinationKafkaResource struct {
 
 // DestinationKafkaResourceModel describes the resource data model.
 type DestinationKafkaResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	Connector          types.String `tfsdk:"connector"`
-	KafkaSinkBootstrap types.String `tfsdk:"kafka_sink_bootstrap"`
-	Format             types.String `tfsdk:"destination_format"`
-	JsonSchemaEnable   types.Bool   `tfsdk:"json_schema_enable"`
-	SchemaRegistryUrl  types.String `tfsdk:"schema_registry_url"`
-	TopicPrefix        types.String `tfsdk:"topic_prefix"`
-	TopicSuffix        types.String `tfsdk:"topic_suffix"`
-	TasksMax           types.Int64  `tfsdk:"tasks_max"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Connector          types.String   `tfsdk:"connector"`
+	KafkaSinkBootstrap types.String   `tfsdk:"kafka_sink_bootstrap"`
+	Format             types.String   `tfsdk:"destination_format"`
+	JsonSchemaEnable   types.Bool     `tfsdk:"json_schema_enable"`
+	SchemaRegistryUrl  types.String   `tfsdk:"schema_registry_url"`
+	TopicPrefix        types.String   `tfsdk:"topic_prefix"`
+	TopicSuffix        types.String   `tfsdk:"topic_suffix"`
+	TasksMax           types.Int64    `tfsdk:"tasks_max"`
+	WaitForRunning     types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *DestinationKafkaResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -134,8 +137,14 @@ func (r *DestinationKafkaResource) Schema(ctx context.Context, req res.SchemaReq
 					int64validator.Between(1, 100),
 				},
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableDestinationAttributes[r.connector_code])
 }
 
 func (r *DestinationKafkaResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -161,12 +170,21 @@ func (r *DestinationKafkaResource) Create(ctx context.Context, req res.CreateReq
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Pre CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config, err := r.model2ConfigMap(plan)
 	if err != nil {
@@ -198,6 +216,17 @@ func (r *DestinationKafkaResource) Create(ctx context.Context, req res.CreateReq
 	r.configMap2Model(destination.Config, &plan)
 	tflog.Debug(ctx, "Post CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	// A destination that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating Kafka destination",
+				fmt.Sprintf("Unable to create Kafka destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -210,6 +239,7 @@ func (r *DestinationKafkaResource) Read(ctx context.Context, req res.ReadRequest
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -217,6 +247,10 @@ func (r *DestinationKafkaResource) Read(ctx context.Context, req res.ReadRequest
 
 	destinationID := state.ID.ValueString()
 	destination, err := r.client.GetDestination(ctx, destinationID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Kafka destination",
@@ -224,16 +258,17 @@ func (r *DestinationKafkaResource) Read(ctx context.Context, req res.ReadRequest
 		)
 		return
 	}
-	if destination == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(destination.Name)
 	state.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &state)
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", state))
 
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -246,9 +281,18 @@ func (r *DestinationKafkaResource) Update(ctx context.Context, req res.UpdateReq
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	config, err := r.model2ConfigMap(plan)
 	if err != nil {
@@ -277,6 +321,16 @@ func (r *DestinationKafkaResource) Update(ctx context.Context, req res.UpdateReq
 	plan.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &plan)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Kafka destination",
+				fmt.Sprintf("Unable to update Kafka destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -289,9 +343,18 @@ func (r *DestinationKafkaResource) Delete(ctx context.Context, req res.DeleteReq
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	err := r.client.DeleteDestination(ctx, state.ID.ValueString())
 	if err != nil {
@@ -304,7 +367,7 @@ func (r *DestinationKafkaResource) Delete(ctx context.Context, req res.DeleteReq
 }
 
 func (r *DestinationKafkaResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportDestinationState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -2,8 +2,10 @@ package destination
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -24,9 +26,10 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ res.Resource                = &DestinationPostgresqlResource{}
-	_ res.ResourceWithConfigure   = &DestinationPostgresqlResource{}
-	_ res.ResourceWithImportState = &DestinationPostgresqlResource{}
+	_ res.Resource                     = &DestinationPostgresqlResource{}
+	_ res.ResourceWithConfigure        = &DestinationPostgresqlResource{}
+	_ res.ResourceWithConfigValidators = &DestinationPostgresqlResource{}
+	_ res.ResourceWithImportState      = &DestinationPostgresqlResource{}
 )
 
 func NewDestinationPostgresqlResource() res.Resource {
@@ -41,26 +44,30 @@ type DestinationPostgresqlResource struct {
 
 // DestinationPostgresqlResourceModel describes the resource data model.
 type DestinationPostgresqlResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	Connector          types.String `tfsdk:"connector"`
-	DatabaseHostname   types.String `tfsdk:"database_hostname"`
-	DatabasePort       types.Int64  `tfsdk:"database_port"`
-	DatabaseDbname     types.String `tfsdk:"database_dbname"`
-	DatabaseUsername   types.String `tfsdk:"database_username"`
-	DatabasePassword   types.String `tfsdk:"database_password"`
-	DatabaseSchemaName types.String `tfsdk:"database_schema_name"`
-	SchemaEvolution    types.String `tfsdk:"schema_evolution"`
-	InsertMode         types.String `tfsdk:"insert_mode"`
-	HardDelete         types.Bool   `tfsdk:"hard_delete"`
-	PrimaryKeyMode     types.String `tfsdk:"primary_key_mode"`
-	CustomPrimaryKey   types.String `tfsdk:"custom_primary_key"`
-	TasksMax           types.Int64  `tfsdk:"tasks_max"`
-	SSHEnabled         types.Bool   `tfsdk:"ssh_enabled"`
-	SSHHost            types.String `tfsdk:"ssh_host"`
-	SSHPort            types.String `tfsdk:"ssh_port"`
-	SSHUser            types.String `tfsdk:"ssh_user"`
-	QuoteIdentifiers   types.Bool   `tfsdk:"quote_identifiers"`
+	ID                        types.String   `tfsdk:"id"`
+	Name                      types.String   `tfsdk:"name"`
+	Connector                 types.String   `tfsdk:"connector"`
+	DatabaseHostname          types.String   `tfsdk:"database_hostname"`
+	DatabasePort              types.Int64    `tfsdk:"database_port"`
+	DatabaseDbname            types.String   `tfsdk:"database_dbname"`
+	DatabaseUsername          types.String   `tfsdk:"database_username"`
+	DatabasePassword          types.String   `tfsdk:"database_password"`
+	DatabasePasswordWO        types.String   `tfsdk:"database_password_wo"`
+	DatabasePasswordWOVersion types.Int64    `tfsdk:"database_password_wo_version"`
+	DatabaseSchemaName        types.String   `tfsdk:"database_schema_name"`
+	SchemaEvolution           types.String   `tfsdk:"schema_evolution"`
+	InsertMode                types.String   `tfsdk:"insert_mode"`
+	HardDelete                types.Bool     `tfsdk:"hard_delete"`
+	PrimaryKeyMode            types.String   `tfsdk:"primary_key_mode"`
+	CustomPrimaryKey          types.String   `tfsdk:"custom_primary_key"`
+	TasksMax                  types.Int64    `tfsdk:"tasks_max"`
+	SSHEnabled                types.Bool     `tfsdk:"ssh_enabled"`
+	SSHHost                   types.String   `tfsdk:"ssh_host"`
+	SSHPort                   types.String   `tfsdk:"ssh_port"`
+	SSHUser                   types.String   `tfsdk:"ssh_user"`
+	QuoteIdentifiers          types.Bool     `tfsdk:"quote_identifiers"`
+	WaitForRunning            types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *DestinationPostgresqlResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -114,11 +121,16 @@ func (r *DestinationPostgresqlResource) Schema(ctx context.Context, req res.Sche
 				MarkdownDescription: "Username to access Postgresql",
 			},
 			"database_password": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "Password to access Postgresql",
-				MarkdownDescription: "Password to access Postgresql",
+				Description:         "Password to access Postgresql. Exactly one of database_password or database_password_wo must be set.",
+				MarkdownDescription: "Password to access Postgresql. Exactly one of `database_password` or `database_password_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("database_password_wo")),
+				},
 			},
+			"database_password_wo":         helper.WriteOnlyAttribute("database_password"),
+			"database_password_wo_version": helper.WriteOnlyVersionAttribute("database_password"),
 			"database_schema_name": schema.StringAttribute{
 				Required:            true,
 				Description:         "Schema for the associated table name",
@@ -220,8 +232,22 @@ func (r *DestinationPostgresqlResource) Schema(ctx context.Context, req res.Sche
 				Description:         "Whether to quote identifiers in SQL statements",
 				MarkdownDescription: "Whether to quote identifiers in SQL statements",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableDestinationAttributes[r.connector_code])
+}
+
+// ConfigValidators reports conflicting options at plan time rather than
+// during the apply.
+func (r *DestinationPostgresqlResource) ConfigValidators(ctx context.Context) []res.ConfigValidator {
+	return []res.ConfigValidator{
+		helper.RequiredWhenTrue("ssh_enabled", "ssh_host"),
+	}
 }
 
 func (r *DestinationPostgresqlResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -247,12 +273,24 @@ func (r *DestinationPostgresqlResource) Create(ctx context.Context, req res.Crea
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("database_password_wo"), &plan.DatabasePasswordWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Pre CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(plan)
 
@@ -277,6 +315,17 @@ func (r *DestinationPostgresqlResource) Create(ctx context.Context, req res.Crea
 	r.configMap2Model(destination.Config, &plan)
 	tflog.Debug(ctx, "Post CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	// A destination that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating Postgresql destination",
+				fmt.Sprintf("Unable to create Postgresql destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -289,6 +338,8 @@ func (r *DestinationPostgresqlResource) Read(ctx context.Context, req res.ReadRe
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -296,6 +347,10 @@ func (r *DestinationPostgresqlResource) Read(ctx context.Context, req res.ReadRe
 
 	destinationID := state.ID.ValueString()
 	destination, err := r.client.GetDestination(ctx, destinationID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Postgresql destination",
@@ -303,16 +358,17 @@ func (r *DestinationPostgresqlResource) Read(ctx context.Context, req res.ReadRe
 		)
 		return
 	}
-	if destination == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(destination.Name)
 	state.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &state)
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", state))
 
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -325,10 +381,22 @@ func (r *DestinationPostgresqlResource) Update(ctx context.Context, req res.Upda
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("database_password_wo"), &plan.DatabasePasswordWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	config := r.model2ConfigMap(plan)
 
 	destination, err := r.client.UpdateDestination(ctx, plan.ID.ValueString(), api.Destination{
@@ -349,6 +417,16 @@ func (r *DestinationPostgresqlResource) Update(ctx context.Context, req res.Upda
 	plan.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &plan)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Postgresql destination",
+				fmt.Sprintf("Unable to update Postgresql destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -361,9 +439,19 @@ func (r *DestinationPostgresqlResource) Delete(ctx context.Context, req res.Dele
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	err := r.client.DeleteDestination(ctx, state.ID.ValueString())
 	if err != nil {
@@ -376,7 +464,7 @@ func (r *DestinationPostgresqlResource) Delete(ctx context.Context, req res.Dele
 }
 
 func (r *DestinationPostgresqlResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportDestinationState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -386,7 +474,7 @@ func (r *DestinationPostgresqlResource) model2ConfigMap(model DestinationPostgre
 		"database.port.user.defined":     int(model.DatabasePort.ValueInt64()), //? need to convert to 32???
 		"database.database.user.defined": model.DatabaseDbname.ValueString(),
 		"connection.username":            model.DatabaseUsername.ValueString(),
-		"connection.password":            model.DatabasePassword.ValueString(),
+		"connection.password":            helper.PreferWriteOnly(model.DatabasePasswordWO, model.DatabasePassword).ValueString(),
 		"table.name.prefix":              model.DatabaseSchemaName.ValueString(),
 		"schema.evolution":               model.SchemaEvolution.ValueString(),
 		"insert.mode":                    model.InsertMode.ValueString(),
@@ -410,7 +498,10 @@ func (r *DestinationPostgresqlResource) configMap2Model(cfg map[string]any, mode
 	model.DatabasePort = helper.GetTfCfgInt64(cfg, "database.port.user.defined")
 	model.DatabaseDbname = helper.GetTfCfgString(cfg, "database.database.user.defined")
 	model.DatabaseUsername = helper.GetTfCfgString(cfg, "connection.username")
-	model.DatabasePassword = helper.GetTfCfgString(cfg, "connection.password")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.DatabasePassword = helper.GetTfCfgString(cfg, "connection.password")
+	}
 	model.DatabaseSchemaName = helper.GetTfCfgString(cfg, "table.name.prefix")
 	model.SchemaEvolution = helper.GetTfCfgString(cfg, "schema.evolution")
 	model.InsertMode = helper.GetTfCfgString(cfg, "insert.mode")
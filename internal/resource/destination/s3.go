@@ -2,8 +2,10 @@ package destination
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -42,18 +44,22 @@ type DestinationS3Resource struct {
 
 // DestinationS3ResourceModel describes the resource data model.
 type DestinationS3ResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	Connector        types.String `tfsdk:"connector"`
-	AWSAccessKeyID   types.String `tfsdk:"aws_access_key"`
-	AWSSecretKeyID   types.String `tfsdk:"aws_secret_key"`
-	Region           types.String `tfsdk:"aws_region"`
-	BucketName       types.String `tfsdk:"bucket_name"`
-	Format           types.String `tfsdk:"format"`
-	FilenameTemplate types.String `tfsdk:"filename_template"`
-	FilenamePrefix   types.String `tfsdk:"filename_prefix"`
-	CompressionType  types.String `tfsdk:"compression_type"`
-	OutputFields     types.List   `tfsdk:"output_fields"`
+	ID                      types.String   `tfsdk:"id"`
+	Name                    types.String   `tfsdk:"name"`
+	Connector               types.String   `tfsdk:"connector"`
+	AWSAccessKeyID          types.String   `tfsdk:"aws_access_key"`
+	AWSSecretKeyID          types.String   `tfsdk:"aws_secret_key"`
+	AWSSecretKeyIDWO        types.String   `tfsdk:"aws_secret_key_wo"`
+	AWSSecretKeyIDWOVersion types.Int64    `tfsdk:"aws_secret_key_wo_version"`
+	Region                  types.String   `tfsdk:"aws_region"`
+	BucketName              types.String   `tfsdk:"bucket_name"`
+	Format                  types.String   `tfsdk:"format"`
+	FilenameTemplate        types.String   `tfsdk:"filename_template"`
+	FilenamePrefix          types.String   `tfsdk:"filename_prefix"`
+	CompressionType         types.String   `tfsdk:"compression_type"`
+	OutputFields            types.List     `tfsdk:"output_fields"`
+	WaitForRunning          types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *DestinationS3Resource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -90,11 +96,16 @@ func (r *DestinationS3Resource) Schema(ctx context.Context, req res.SchemaReques
 				MarkdownDescription: "The AWS Access Key ID used to connect to S3.",
 			},
 			"aws_secret_key": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "The AWS Secret Access Key used to connect to S3.",
-				MarkdownDescription: "The AWS Secret Access Key used to connect to S3.",
+				Description:         "The AWS Secret Access Key used to connect to S3. Exactly one of aws_secret_key or aws_secret_key_wo must be set.",
+				MarkdownDescription: "The AWS Secret Access Key used to connect to S3. Exactly one of `aws_secret_key` or `aws_secret_key_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("aws_secret_key_wo")),
+				},
 			},
+			"aws_secret_key_wo":         helper.WriteOnlyAttribute("aws_secret_key"),
+			"aws_secret_key_wo_version": helper.WriteOnlyVersionAttribute("aws_secret_key"),
 			"aws_region": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -189,8 +200,14 @@ func (r *DestinationS3Resource) Schema(ctx context.Context, req res.SchemaReques
 					)),
 				},
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableDestinationAttributes[r.connector_code])
 }
 
 func (r *DestinationS3Resource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -216,12 +233,24 @@ func (r *DestinationS3Resource) Create(ctx context.Context, req res.CreateReques
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("aws_secret_key_wo"), &plan.AWSSecretKeyIDWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Pre CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config, diag := r.model2ConfigMap(plan, ctx)
 
@@ -250,6 +279,17 @@ func (r *DestinationS3Resource) Create(ctx context.Context, req res.CreateReques
 	r.configMap2Model(destination.Config, &plan, ctx)
 	tflog.Debug(ctx, "Post CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	// A destination that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating S3 destination",
+				fmt.Sprintf("Unable to create S3 destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -262,6 +302,8 @@ func (r *DestinationS3Resource) Read(ctx context.Context, req res.ReadRequest, r
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -269,6 +311,10 @@ func (r *DestinationS3Resource) Read(ctx context.Context, req res.ReadRequest, r
 
 	destinationID := state.ID.ValueString()
 	destination, err := r.client.GetDestination(ctx, destinationID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading S3 destination",
@@ -276,16 +322,17 @@ func (r *DestinationS3Resource) Read(ctx context.Context, req res.ReadRequest, r
 		)
 		return
 	}
-	if destination == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(destination.Name)
 	state.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &state, ctx)
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", state))
 
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -298,9 +345,21 @@ func (r *DestinationS3Resource) Update(ctx context.Context, req res.UpdateReques
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("aws_secret_key_wo"), &plan.AWSSecretKeyIDWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	config, diag := r.model2ConfigMap(plan, ctx)
 
@@ -326,6 +385,16 @@ func (r *DestinationS3Resource) Update(ctx context.Context, req res.UpdateReques
 	plan.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(destination.Config, &plan, ctx)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating S3 destination",
+				fmt.Sprintf("Unable to update S3 destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -338,10 +407,20 @@ func (r *DestinationS3Resource) Delete(ctx context.Context, req res.DeleteReques
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeleteDestination(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -353,7 +432,7 @@ func (r *DestinationS3Resource) Delete(ctx context.Context, req res.DeleteReques
 }
 
 func (r *DestinationS3Resource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportDestinationState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -367,7 +446,7 @@ func (r *DestinationS3Resource) model2ConfigMap(model DestinationS3ResourceModel
 
 	configMap := map[string]any{
 		"aws.access.key.id":                 model.AWSAccessKeyID.ValueString(),
-		"aws.secret.access.key":             model.AWSSecretKeyID.ValueString(),
+		"aws.secret.access.key":             helper.PreferWriteOnly(model.AWSSecretKeyIDWO, model.AWSSecretKeyID).ValueString(),
 		"aws.s3.region":                     model.Region.ValueString(),
 		"aws.s3.bucket.name":                model.BucketName.ValueString(),
 		"format.user.defined":               model.Format.ValueString(),
@@ -383,7 +462,10 @@ func (r *DestinationS3Resource) model2ConfigMap(model DestinationS3ResourceModel
 func (r *DestinationS3Resource) configMap2Model(cfg map[string]any, model *DestinationS3ResourceModel, ctx context.Context) {
 	// Copy the config map to the model
 	model.AWSAccessKeyID = helper.GetTfCfgString(cfg, "aws.access.key.id")
-	model.AWSSecretKeyID = helper.GetTfCfgString(cfg, "aws.secret.access.key")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.AWSSecretKeyID = helper.GetTfCfgString(cfg, "aws.secret.access.key")
+	}
 	model.Region = helper.GetTfCfgString(cfg, "aws.s3.region")
 	model.BucketName = helper.GetTfCfgString(cfg, "aws.s3.bucket.name")
 	model.Format = helper.GetTfCfgString(cfg, "format.user.defined")
@@ -2,9 +2,11 @@ package destination
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
@@ -40,29 +42,35 @@ type DestinationSnowflakeResource struct {
 
 // DestinationSnowflakeResourceModel describes the resource data model.
 type DestinationSnowflakeResourceModel struct {
-	ID                            types.String            `tfsdk:"id"`
-	Name                          types.String            `tfsdk:"name"`
-	Connector                     types.String            `tfsdk:"connector"`
-	SnowflakeUrlName              types.String            `tfsdk:"snowflake_url_name"`
-	SnowflakeUserName             types.String            `tfsdk:"snowflake_user_name"`
-	SnowflakePrivateKey           types.String            `tfsdk:"snowflake_private_key"`
-	SnowflakePrivateKeyPassphrase types.String            `tfsdk:"snowflake_private_key_passphrase"`
-	Sfwarehouse                   types.String            `tfsdk:"sfwarehouse"`
-	SnowflakeDatabaseName         types.String            `tfsdk:"snowflake_database_name"`
-	SnowflakeSchemaName           types.String            `tfsdk:"snowflake_schema_name"`
-	AutoSchemaCreation            types.Bool              `tfsdk:"auto_schema_creation"`
-	SnowflakeRoleName             types.String            `tfsdk:"snowflake_role_name"`
-	IngestionMode                 types.String            `tfsdk:"ingestion_mode"`
-	HardDelete                    types.Bool              `tfsdk:"hard_delete"`
-	SchemaEvolution               types.String            `tfsdk:"schema_evolution"`
-	UseHybridTables               types.Bool              `tfsdk:"use_hybrid_tables"`
-	ApplyDynamicTableScript       types.Bool              `tfsdk:"apply_dynamic_table_script"`
-	CreateSQLExecute              types.String            `tfsdk:"create_sql_execute"`
-	CreateSQLData                 types.String            `tfsdk:"create_sql_data"`
-	SQLTableName                  types.String            `tfsdk:"sql_table_name"`
-	AutoQADedupeTableMapping      map[string]types.String `tfsdk:"auto_qa_dedupe_table_mapping"`
-	SnowflakeTopic2TableMap       types.String            `tfsdk:"snowflake_topic2table_map"`
-	QuoteIdentifiers              types.Bool              `tfsdk:"quote_identifiers"`
+	ID                                     types.String            `tfsdk:"id"`
+	Name                                   types.String            `tfsdk:"name"`
+	Connector                              types.String            `tfsdk:"connector"`
+	SnowflakeUrlName                       types.String            `tfsdk:"snowflake_url_name"`
+	SnowflakeUserName                      types.String            `tfsdk:"snowflake_user_name"`
+	SnowflakePrivateKey                    types.String            `tfsdk:"snowflake_private_key"`
+	SnowflakePrivateKeyWO                  types.String            `tfsdk:"snowflake_private_key_wo"`
+	SnowflakePrivateKeyWOVersion           types.Int64             `tfsdk:"snowflake_private_key_wo_version"`
+	SnowflakePrivateKeyPassphrase          types.String            `tfsdk:"snowflake_private_key_passphrase"`
+	SnowflakePrivateKeyPassphraseWO        types.String            `tfsdk:"snowflake_private_key_passphrase_wo"`
+	SnowflakePrivateKeyPassphraseWOVersion types.Int64             `tfsdk:"snowflake_private_key_passphrase_wo_version"`
+	Sfwarehouse                            types.String            `tfsdk:"sfwarehouse"`
+	SnowflakeDatabaseName                  types.String            `tfsdk:"snowflake_database_name"`
+	SnowflakeSchemaName                    types.String            `tfsdk:"snowflake_schema_name"`
+	AutoSchemaCreation                     types.Bool              `tfsdk:"auto_schema_creation"`
+	SnowflakeRoleName                      types.String            `tfsdk:"snowflake_role_name"`
+	IngestionMode                          types.String            `tfsdk:"ingestion_mode"`
+	HardDelete                             types.Bool              `tfsdk:"hard_delete"`
+	SchemaEvolution                        types.String            `tfsdk:"schema_evolution"`
+	UseHybridTables                        types.Bool              `tfsdk:"use_hybrid_tables"`
+	ApplyDynamicTableScript                types.Bool              `tfsdk:"apply_dynamic_table_script"`
+	CreateSQLExecute                       types.String            `tfsdk:"create_sql_execute"`
+	CreateSQLData                          types.String            `tfsdk:"create_sql_data"`
+	SQLTableName                           types.String            `tfsdk:"sql_table_name"`
+	AutoQADedupeTableMapping               map[string]types.String `tfsdk:"auto_qa_dedupe_table_mapping"`
+	SnowflakeTopic2TableMap                types.String            `tfsdk:"snowflake_topic2table_map"`
+	QuoteIdentifiers                       types.Bool              `tfsdk:"quote_identifiers"`
+	WaitForRunning                         types.Bool              `tfsdk:"wait_for_running"`
+	Timeouts                               timeouts.Value          `tfsdk:"timeouts"`
 }
 
 func (r *DestinationSnowflakeResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -104,17 +112,27 @@ func (r *DestinationSnowflakeResource) Schema(ctx context.Context, req res.Schem
 				MarkdownDescription: "User login name for the Snowflake account.",
 			},
 			"snowflake_private_key": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "The private key to authenticate the user. Include only the key, not the header or footer. If the key is split across multiple lines, remove the line breaks.",
-				MarkdownDescription: "The private key to authenticate the user. Include only the key, not the header or footer. If the key is split across multiple lines, remove the line breaks.",
+				Description:         "The private key to authenticate the user. Include only the key, not the header or footer. If the key is split across multiple lines, remove the line breaks. Exactly one of snowflake_private_key or snowflake_private_key_wo must be set.",
+				MarkdownDescription: "The private key to authenticate the user. Include only the key, not the header or footer. If the key is split across multiple lines, remove the line breaks. Exactly one of `snowflake_private_key` or `snowflake_private_key_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("snowflake_private_key_wo")),
+				},
 			},
+			"snowflake_private_key_wo":         helper.WriteOnlyAttribute("snowflake_private_key"),
+			"snowflake_private_key_wo_version": helper.WriteOnlyVersionAttribute("snowflake_private_key"),
 			"snowflake_private_key_passphrase": schema.StringAttribute{
 				Optional:            true,
 				Sensitive:           true,
-				Description:         "If the value is not empty, this phrase is used to try to decrypt the private key.",
-				MarkdownDescription: "If the value is not empty, this phrase is used to try to decrypt the private key.",
+				Description:         "If the value is not empty, this phrase is used to try to decrypt the private key. Conflicts with snowflake_private_key_passphrase_wo.",
+				MarkdownDescription: "If the value is not empty, this phrase is used to try to decrypt the private key. Conflicts with `snowflake_private_key_passphrase_wo`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("snowflake_private_key_passphrase_wo")),
+				},
 			},
+			"snowflake_private_key_passphrase_wo":         helper.WriteOnlyAttribute("snowflake_private_key_passphrase"),
+			"snowflake_private_key_passphrase_wo_version": helper.WriteOnlyVersionAttribute("snowflake_private_key_passphrase"),
 			"sfwarehouse": schema.StringAttribute{
 				Computed:            true,
 				Optional:            true,
@@ -253,8 +271,14 @@ func (r *DestinationSnowflakeResource) Schema(ctx context.Context, req res.Schem
 				Description:         "Whether to quote identifiers in SQL statements",
 				MarkdownDescription: "Whether to quote identifiers in SQL statements",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableDestinationAttributes[r.connector_code])
 }
 
 func (r *DestinationSnowflakeResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -280,12 +304,25 @@ func (r *DestinationSnowflakeResource) Create(ctx context.Context, req res.Creat
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("snowflake_private_key_wo"), &plan.SnowflakePrivateKeyWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("snowflake_private_key_passphrase_wo"), &plan.SnowflakePrivateKeyPassphraseWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Pre CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(ctx, plan)
 
@@ -311,6 +348,17 @@ func (r *DestinationSnowflakeResource) Create(ctx context.Context, req res.Creat
 	r.configMap2Model(ctx, destination.Config, &plan)
 	tflog.Debug(ctx, "Post CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	// A destination that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating Snowflake destination",
+				fmt.Sprintf("Unable to create Snowflake destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -323,6 +371,8 @@ func (r *DestinationSnowflakeResource) Read(ctx context.Context, req res.ReadReq
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -330,6 +380,10 @@ func (r *DestinationSnowflakeResource) Read(ctx context.Context, req res.ReadReq
 
 	destinationID := state.ID.ValueString()
 	destination, err := r.client.GetDestination(ctx, destinationID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Snowflake destination",
@@ -337,15 +391,16 @@ func (r *DestinationSnowflakeResource) Read(ctx context.Context, req res.ReadReq
 		)
 		return
 	}
-	if destination == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(destination.Name)
 	state.Connector = types.StringValue(destination.Connector)
 	r.configMap2Model(ctx, destination.Config, &state)
 
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -358,9 +413,22 @@ func (r *DestinationSnowflakeResource) Update(ctx context.Context, req res.Updat
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("snowflake_private_key_wo"), &plan.SnowflakePrivateKeyWO)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("snowflake_private_key_passphrase_wo"), &plan.SnowflakePrivateKeyPassphraseWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	tflog.Debug(ctx, "Pre UPDATE ===> plan: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(ctx, plan)
@@ -387,6 +455,16 @@ func (r *DestinationSnowflakeResource) Update(ctx context.Context, req res.Updat
 	r.configMap2Model(ctx, destination.Config, &plan)
 	tflog.Debug(ctx, "Post UPDATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetDestinationStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Snowflake destination",
+				fmt.Sprintf("Unable to update Snowflake destination, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -399,9 +477,19 @@ func (r *DestinationSnowflakeResource) Delete(ctx context.Context, req res.Delet
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	err := r.client.DeleteDestination(ctx, state.ID.ValueString())
 	if err != nil {
@@ -414,7 +502,7 @@ func (r *DestinationSnowflakeResource) Delete(ctx context.Context, req res.Delet
 }
 
 func (r *DestinationSnowflakeResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportDestinationState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -435,9 +523,9 @@ func (r *DestinationSnowflakeResource) model2ConfigMap(_ context.Context, model
 	configMap := map[string]any{
 		"snowflake.url.name":                       model.SnowflakeUrlName.ValueString(),
 		"snowflake.user.name":                      model.SnowflakeUserName.ValueString(),
-		"snowflake.private.key":                    model.SnowflakePrivateKey.ValueString(),
+		"snowflake.private.key":                    helper.PreferWriteOnly(model.SnowflakePrivateKeyWO, model.SnowflakePrivateKey).ValueString(),
 		"snowflake.private.key.passphrase.secured": true,
-		"snowflake.private.key.passphrase":         model.SnowflakePrivateKeyPassphrase.ValueStringPointer(),
+		"snowflake.private.key.passphrase":         helper.PreferWriteOnly(model.SnowflakePrivateKeyPassphraseWO, model.SnowflakePrivateKeyPassphrase).ValueStringPointer(),
 		"sfwarehouse":                              model.Sfwarehouse.ValueString(),
 		"snowflake.database.name":                  model.SnowflakeDatabaseName.ValueString(),
 		"snowflake.schema.name":                    model.SnowflakeSchemaName.ValueString(),
@@ -456,7 +544,7 @@ func (r *DestinationSnowflakeResource) model2ConfigMap(_ context.Context, model
 		"quote.identifiers":                        model.QuoteIdentifiers.ValueBool(),
 	}
 
-	if model.SnowflakePrivateKeyPassphrase.IsNull() {
+	if helper.PreferWriteOnly(model.SnowflakePrivateKeyPassphraseWO, model.SnowflakePrivateKeyPassphrase).IsNull() {
 		configMap["snowflake.private.key.passphrase.secured"] = false
 	}
 
@@ -467,8 +555,11 @@ func (r *DestinationSnowflakeResource) configMap2Model(ctx context.Context, cfg
 	// Copy the config map to the model
 	model.SnowflakeUrlName = helper.GetTfCfgString(cfg, "snowflake.url.name")
 	model.SnowflakeUserName = helper.GetTfCfgString(cfg, "snowflake.user.name")
-	model.SnowflakePrivateKey = helper.GetTfCfgString(cfg, "snowflake.private.key")
-	model.SnowflakePrivateKeyPassphrase = helper.GetTfCfgString(cfg, "snowflake.private.key.passphrase")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.SnowflakePrivateKey = helper.GetTfCfgString(cfg, "snowflake.private.key")
+		model.SnowflakePrivateKeyPassphrase = helper.GetTfCfgString(cfg, "snowflake.private.key.passphrase")
+	}
 	model.Sfwarehouse = helper.GetTfCfgString(cfg, "sfwarehouse")
 	model.SnowflakeDatabaseName = helper.GetTfCfgString(cfg, "snowflake.database.name")
 	model.SnowflakeSchemaName = helper.GetTfCfgString(cfg, "snowflake.schema.name")
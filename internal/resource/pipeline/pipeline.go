@@ -2,9 +2,16 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	stdpath "path"
+	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -12,11 +19,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/constants"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/helper"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,12 +35,18 @@ var (
 	_ res.Resource                = &PipelineResource{}
 	_ res.ResourceWithConfigure   = &PipelineResource{}
 	_ res.ResourceWithImportState = &PipelineResource{}
+	_ res.ResourceWithModifyPlan  = &PipelineResource{}
 )
 
 func NewPipelineResource() res.Resource {
 	return &PipelineResource{}
 }
 
+const (
+	pipelineStateRunning = "running"
+	pipelineStatePaused  = "paused"
+)
+
 // PipelineResource defines the res implementation.
 type PipelineResource struct {
 	client api.StreamkapAPI
@@ -44,6 +61,11 @@ type PipelineResourceModel struct {
 	Destination       *PipelineDestinationModel `tfsdk:"destination"`
 	Transforms        []*PipelineTransformModel `tfsdk:"transforms"`
 	Tags              types.Set                 `tfsdk:"tags"`
+	State             types.String              `tfsdk:"state"`
+	Status            types.String              `tfsdk:"status"`
+	RestartOnChange   types.Map                 `tfsdk:"restart_on_change"`
+	WaitForRunning    types.Bool                `tfsdk:"wait_for_running"`
+	Timeouts          timeouts.Value            `tfsdk:"timeouts"`
 }
 
 type PipelineSourceModel struct {
@@ -60,8 +82,10 @@ type PipelineDestinationModel struct {
 }
 
 type PipelineTransformModel struct {
-	ID     types.String `tfsdk:"id"`
-	Topics types.Set    `tfsdk:"topics"`
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Topics       types.Set    `tfsdk:"topics"`
+	TopicPattern types.String `tfsdk:"topic_pattern"`
 }
 
 func (r *PipelineResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -71,10 +95,12 @@ func (r *PipelineResource) Metadata(ctx context.Context, req res.MetadataRequest
 func (r *PipelineResource) Schema(ctx context.Context, req res.SchemaRequest, resp *res.SchemaResponse) {
 	transformsNestedObjectType := types.ObjectType{
 		AttrTypes: map[string]attr.Type{
-			"id": types.StringType,
+			"id":   types.StringType,
+			"name": types.StringType,
 			"topics": types.SetType{
 				ElemType: types.StringType,
 			},
+			"topic_pattern": types.StringType,
 		},
 	}
 
@@ -155,15 +181,36 @@ func (r *PipelineResource) Schema(ctx context.Context, req res.SchemaRequest, re
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
-							Required:            true,
-							Description:         "Transform identifier",
-							MarkdownDescription: "Transform identifier",
+							Optional:            true,
+							Computed:            true,
+							Description:         "Transform identifier. Exactly one of id or name must be set.",
+							MarkdownDescription: "Transform identifier. Exactly one of `id` or `name` must be set.",
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("name")),
+							},
+						},
+						"name": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Description:         "Transform name, resolved to its identifier at plan time",
+							MarkdownDescription: "Transform name, resolved to its identifier at plan time",
 						},
 						"topics": schema.SetAttribute{
-							Required:            true,
+							Optional:            true,
+							Computed:            true,
 							ElementType:         types.StringType,
-							Description:         "List of transform topics' names",
-							MarkdownDescription: "List of transform topics' names",
+							Description:         "List of transform topics' names. Exactly one of topics or topic_pattern must be set.",
+							MarkdownDescription: "List of transform topics' names. Exactly one of `topics` or `topic_pattern` must be set.",
+						},
+						"topic_pattern": schema.StringAttribute{
+							Optional: true,
+							Description: "Pattern selecting the transform topics, resolved into topics at plan time. " +
+								"A topic matches when it matches the pattern as a glob (public.*) or as a regular expression anchored at both ends (public\\.(users|orders)).",
+							MarkdownDescription: "Pattern selecting the transform topics, resolved into `topics` at plan time. " +
+								"A topic matches when it matches the pattern as a glob (`public.*`) or as a regular expression anchored at both ends (`public\\.(users|orders)`).",
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("topics")),
+							},
 						},
 					},
 				},
@@ -177,6 +224,33 @@ func (r *PipelineResource) Schema(ctx context.Context, req res.SchemaRequest, re
 				ElementType:         types.StringType,
 				Default:             setdefault.StaticValue(defaultTagsWithDev),
 			},
+			"state": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(pipelineStateRunning),
+				Description:         "Desired state of the pipeline, running or paused. Default is running.",
+				MarkdownDescription: "Desired state of the pipeline, `running` or `paused`. Default is `running`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(pipelineStateRunning, pipelineStatePaused),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Health of the pipeline's connector as reported by Streamkap, e.g. RUNNING, PAUSED or FAILED. Null when Streamkap does not report it.",
+				MarkdownDescription: "Health of the pipeline's connector as reported by Streamkap, e.g. `RUNNING`, `PAUSED` or `FAILED`. Null when Streamkap does not report it.",
+			},
+			"restart_on_change": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary map of values that, when changed, restarts the running pipeline, " +
+					"like triggers on terraform_data. E.g. the hash of a schema migration.",
+				MarkdownDescription: "Arbitrary map of values that, when changed, restarts the running pipeline, " +
+					"like `triggers` on `terraform_data`. E.g. the hash of a schema migration.",
+			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -201,6 +275,34 @@ func (r *PipelineResource) Configure(ctx context.Context, req res.ConfigureReque
 	r.client = client
 }
 
+// ModifyPlan resolves the transforms referenced by name and the topics
+// selected by topic_pattern, so that the plan shows the actual topics and
+// unmatched patterns fail before the apply.
+func (r *PipelineResource) ModifyPlan(ctx context.Context, req res.ModifyPlanRequest, resp *res.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var transformList types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("transforms"), &transformList)...)
+	if resp.Diagnostics.HasError() || transformList.IsNull() || transformList.IsUnknown() {
+		return
+	}
+	var transforms []*PipelineTransformModel
+	resp.Diagnostics.Append(transformList.ElementsAs(ctx, &transforms, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.resolveTransforms(ctx, transforms, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("transforms"), transforms)...)
+}
+
 func (r *PipelineResource) Create(ctx context.Context, req res.CreateRequest, resp *res.CreateResponse) {
 	var plan PipelineResourceModel
 
@@ -211,6 +313,14 @@ func (r *PipelineResource) Create(ctx context.Context, req res.CreateRequest, re
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	payload, err := r.model2API(ctx, plan)
@@ -234,6 +344,10 @@ func (r *PipelineResource) Create(ctx context.Context, req res.CreateRequest, re
 	// save into the Terraform state.
 	r.api2Model(ctx, *pipeline, &plan)
 
+	// The pipeline exists from here on, it is saved even when setting its
+	// state fails so that it is not orphaned.
+	resp.Diagnostics.Append(r.applyState(ctx, &plan, nil)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -253,6 +367,10 @@ func (r *PipelineResource) Read(ctx context.Context, req res.ReadRequest, resp *
 
 	pipelineID := state.ID.ValueString()
 	pipeline, err := r.client.GetPipeline(ctx, pipelineID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading pipeline",
@@ -260,13 +378,24 @@ func (r *PipelineResource) Read(ctx context.Context, req res.ReadRequest, resp *
 		)
 		return
 	}
-	if pipeline == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	r.api2Model(ctx, *pipeline, &state)
 
+	// The status is informative: when it cannot be read, the state is kept
+	// from the prior state rather than failing the refresh.
+	state.Status = r.readStatus(ctx, pipelineID)
+	switch {
+	case state.Status.ValueString() == api.ConnectorStatusPaused:
+		state.State = types.StringValue(pipelineStatePaused)
+	case !state.Status.IsNull() || state.State.IsNull():
+		state.State = types.StringValue(pipelineStateRunning)
+	}
+
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -275,13 +404,22 @@ func (r *PipelineResource) Read(ctx context.Context, req res.ReadRequest, resp *
 }
 
 func (r *PipelineResource) Update(ctx context.Context, req res.UpdateRequest, resp *res.UpdateResponse) {
-	var plan PipelineResourceModel
+	var plan, state PipelineResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	payload, err := r.model2API(ctx, plan)
 	if err != nil {
@@ -306,6 +444,8 @@ func (r *PipelineResource) Update(ctx context.Context, req res.UpdateRequest, re
 	// save into the Terraform state.
 	r.api2Model(ctx, *pipeline, &plan)
 
+	resp.Diagnostics.Append(r.applyState(ctx, &plan, &state)...)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -313,6 +453,68 @@ func (r *PipelineResource) Update(ctx context.Context, req res.UpdateRequest, re
 	}
 }
 
+// applyState pauses, resumes or restarts the pipeline as planned, then
+// reads its status, waiting for a running pipeline to start when
+// wait_for_running is set. prior is nil on create. On error the status is
+// left null so that the model can still be saved.
+func (r *PipelineResource) applyState(ctx context.Context, plan, prior *PipelineResourceModel) (diags diag.Diagnostics) {
+	pipelineID := plan.ID.ValueString()
+	plan.Status = types.StringNull()
+
+	var err error
+	switch {
+	case prior == nil && plan.State.ValueString() == pipelineStatePaused:
+		err = r.client.PausePipeline(ctx, pipelineID)
+	case prior == nil:
+	case !plan.State.Equal(prior.State) && plan.State.ValueString() == pipelineStatePaused:
+		err = r.client.PausePipeline(ctx, pipelineID)
+	case !plan.State.Equal(prior.State):
+		err = r.client.ResumePipeline(ctx, pipelineID)
+	case plan.State.ValueString() == pipelineStateRunning && !plan.RestartOnChange.Equal(prior.RestartOnChange):
+		err = r.client.RestartPipeline(ctx, pipelineID)
+	}
+	if err != nil {
+		diags.AddError(
+			"Error setting pipeline state",
+			fmt.Sprintf("Unable to set pipeline %s %s, got error: %s", pipelineID, plan.State.ValueString(), err),
+		)
+		return
+	}
+
+	if plan.WaitForRunning.ValueBool() && plan.State.ValueString() == pipelineStateRunning {
+		status, err := helper.WaitForRunning(ctx, r.client.GetPipelineStatus, pipelineID)
+		if status != nil {
+			plan.Status = types.StringValue(status.State)
+		}
+		if err != nil {
+			diags.AddError(
+				"Error waiting for pipeline",
+				fmt.Sprintf("Pipeline %s is not running, got error: %s", pipelineID, err),
+			)
+		}
+		return
+	}
+
+	plan.Status = r.readStatus(ctx, pipelineID)
+
+	return
+}
+
+// readStatus returns the status of the connector running the pipeline, or
+// null when it cannot be read, e.g. on a backend without the endpoint.
+func (r *PipelineResource) readStatus(ctx context.Context, pipelineID string) types.String {
+	status, err := r.client.GetPipelineStatus(ctx, pipelineID)
+	if errors.Is(err, api.ErrNotFound) {
+		tflog.Debug(ctx, fmt.Sprintf("No status reported for pipeline %s", pipelineID))
+		return types.StringNull()
+	}
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to read the status of pipeline %s, got error: %s", pipelineID, err))
+		return types.StringNull()
+	}
+	return types.StringValue(status.State)
+}
+
 func (r *PipelineResource) Delete(ctx context.Context, req res.DeleteRequest, resp *res.DeleteResponse) {
 	var state PipelineResourceModel
 
@@ -322,6 +524,14 @@ func (r *PipelineResource) Delete(ctx context.Context, req res.DeleteRequest, re
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeletePipeline(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -333,7 +543,7 @@ func (r *PipelineResource) Delete(ctx context.Context, req res.DeleteRequest, re
 }
 
 func (r *PipelineResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportPipelineState(ctx, r.client, req, resp)
 }
 
 // Helpers
@@ -349,15 +559,13 @@ func (r *PipelineResource) idxStringInSlice(a string, list []string) int {
 func (r *PipelineResource) model2APITransforms(ctx context.Context, modelTransforms []*PipelineTransformModel) (res []*api.PipelineTransform, err error) {
 	res = []*api.PipelineTransform{}
 
-	for _, modelTransform := range modelTransforms {
-		transformID := modelTransform.ID.ValueString()
-		transform, err := r.client.GetTransform(ctx, transformID)
-		if err != nil {
-			return nil, err
-		}
-		if transform == nil {
-			return nil, fmt.Errorf("transform %s does not exist", transformID)
-		}
+	transforms, diags := r.resolveTransforms(ctx, modelTransforms, false)
+	if diags.HasError() {
+		return nil, fmt.Errorf("error resolving transforms: %s", diags)
+	}
+
+	for i, modelTransform := range modelTransforms {
+		transform := transforms[i]
 
 		strModelTransformTopics := []string{}
 		diags := modelTransform.Topics.ElementsAs(ctx, &strModelTransformTopics, false)
@@ -375,7 +583,7 @@ func (r *PipelineResource) model2APITransforms(ctx context.Context, modelTransfo
 					TopicID:   transform.TopicIDs[topicIdx],
 				})
 			} else {
-				return nil, fmt.Errorf("topic %s not found in transform %s", strModelTransformTopic, transformID)
+				return nil, fmt.Errorf("topic %s not found in transform %s", strModelTransformTopic, transform.ID)
 			}
 		}
 	}
@@ -383,6 +591,172 @@ func (r *PipelineResource) model2APITransforms(ctx context.Context, modelTransfo
 	return res, nil
 }
 
+// resolveTransforms fills in the id, name and topics of modelTransforms,
+// looking the transforms up by id or name and matching their topics against
+// topic_pattern. It returns the transforms, aligned with modelTransforms.
+// While planning, transforms that cannot be resolved yet, e.g. because they
+// are created in the same apply, are left unknown and nil. When applying,
+// the planned topics are kept and only checked against the transform, so a
+// topic added to it in between does not change the result; the pattern is
+// only matched when the plan left the topics unknown.
+func (r *PipelineResource) resolveTransforms(ctx context.Context, modelTransforms []*PipelineTransformModel, planning bool) ([]*api.Transform, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	transforms := make([]*api.Transform, len(modelTransforms))
+	unmatched := []string{}
+
+	for i, modelTransform := range modelTransforms {
+		attrPath := path.Root("transforms").AtListIndex(i)
+
+		transform, err := r.lookupTransform(ctx, modelTransform)
+		if planning && errors.Is(err, api.ErrNotFound) && modelTransform.ID.IsNull() {
+			diags.AddAttributeWarning(
+				attrPath.AtName("name"),
+				"Transform not found",
+				fmt.Sprintf("%s. It must exist by the time the pipeline is applied.", err),
+			)
+			continue
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				attrPath,
+				"Error resolving pipeline transform",
+				fmt.Sprintf("Unable to resolve transform, got error: %s", err),
+			)
+			continue
+		}
+		if transform == nil {
+			if !planning {
+				diags.AddAttributeError(
+					attrPath,
+					"Error resolving pipeline transform",
+					"The transform id or name is still unknown",
+				)
+			}
+			continue
+		}
+		transforms[i] = transform
+		modelTransform.ID = types.StringValue(transform.ID)
+		modelTransform.Name = types.StringValue(transform.Name)
+
+		switch {
+		case modelTransform.TopicPattern.IsUnknown():
+			modelTransform.Topics = types.SetUnknown(types.StringType)
+		case !modelTransform.TopicPattern.IsNull() && (planning || modelTransform.Topics.IsUnknown()):
+			pattern := modelTransform.TopicPattern.ValueString()
+			match, err := topicMatcher(pattern)
+			if err != nil {
+				diags.AddAttributeError(
+					attrPath.AtName("topic_pattern"),
+					"Invalid topic_pattern",
+					err.Error(),
+				)
+				continue
+			}
+			topics := []string{}
+			for _, topic := range transform.Topics {
+				if match(topic) {
+					topics = append(topics, topic)
+				}
+			}
+			if len(topics) == 0 {
+				unmatched = append(unmatched, fmt.Sprintf("%q for transform %s (%s), whose topics are: %s",
+					pattern, transform.Name, transform.ID, strings.Join(transform.Topics, ", ")))
+			}
+			topicSet, d := types.SetValue(types.StringType, r.strListToTfStrList(topics))
+			diags.Append(d...)
+			modelTransform.Topics = topicSet
+		case !modelTransform.Topics.IsUnknown():
+			topics := []types.String{}
+			diags.Append(modelTransform.Topics.ElementsAs(ctx, &topics, false)...)
+			missing := []string{}
+			for _, topic := range topics {
+				if !topic.IsUnknown() && r.idxStringInSlice(topic.ValueString(), transform.Topics) < 0 {
+					missing = append(missing, topic.ValueString())
+				}
+			}
+			if len(missing) > 0 {
+				diags.AddAttributeError(
+					attrPath.AtName("topics"),
+					"Unknown transform topics",
+					fmt.Sprintf("Topics %s not found in transform %s (%s), whose topics are: %s",
+						strings.Join(missing, ", "), transform.Name, transform.ID, strings.Join(transform.Topics, ", ")),
+				)
+			}
+		}
+	}
+
+	if len(unmatched) > 0 {
+		diags.AddAttributeError(
+			path.Root("transforms"),
+			"Unmatched transform topic patterns",
+			"No topic matches these topic_pattern values:\n  - "+strings.Join(unmatched, "\n  - "),
+		)
+	}
+
+	return transforms, diags
+}
+
+// lookupTransform returns the transform referenced by id or by name, or nil
+// when neither is known yet.
+func (r *PipelineResource) lookupTransform(ctx context.Context, modelTransform *PipelineTransformModel) (*api.Transform, error) {
+	if !modelTransform.ID.IsNull() && !modelTransform.ID.IsUnknown() {
+		transformID := modelTransform.ID.ValueString()
+		transform, err := r.client.GetTransform(ctx, transformID)
+		if errors.Is(err, api.ErrNotFound) {
+			return nil, fmt.Errorf("transform %s does not exist: %w", transformID, err)
+		}
+		return transform, err
+	}
+	if modelTransform.Name.IsNull() || modelTransform.Name.IsUnknown() {
+		return nil, nil
+	}
+
+	name := modelTransform.Name.ValueString()
+	transforms, err := r.client.ListTransforms(ctx, api.ListOptions{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	matches := []api.Transform{}
+	for _, transform := range transforms {
+		if transform.Name == name {
+			matches = append(matches, transform)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no transform is named %q: %w", name, api.ErrNotFound)
+	case 1:
+		// The list endpoint does not unwind the topics, fetch them.
+		return r.client.GetTransform(ctx, matches[0].ID)
+	default:
+		ids := []string{}
+		for _, transform := range matches {
+			ids = append(ids, transform.ID)
+		}
+		return nil, fmt.Errorf("%d transforms are named %q (%s), reference it by id instead", len(matches), name, strings.Join(ids, ", "))
+	}
+}
+
+// topicMatcher returns a function reporting whether a topic matches
+// pattern, either as a glob or as a regular expression anchored at both
+// ends.
+func topicMatcher(pattern string) (func(topic string) bool, error) {
+	_, globErr := stdpath.Match(pattern, "")
+	re, reErr := regexp.Compile("^(?:" + pattern + ")$")
+	if globErr != nil && reErr != nil {
+		return nil, fmt.Errorf("%q is neither a valid glob nor a valid regular expression: %s", pattern, reErr)
+	}
+
+	return func(topic string) bool {
+		if globErr == nil {
+			if ok, _ := stdpath.Match(pattern, topic); ok {
+				return true
+			}
+		}
+		return reErr == nil && re.MatchString(topic)
+	}, nil
+}
+
 func (r *PipelineResource) strListToTfStrList(strList []string) (tfStrList []attr.Value) {
 	tfStrList = make([]attr.Value, len(strList))
 	for i, str := range strList {
@@ -391,43 +765,43 @@ func (r *PipelineResource) strListToTfStrList(strList []string) (tfStrList []att
 	return
 }
 
-func (r *PipelineResource) api2ModelTransforms(_ context.Context, apiTransforms []*api.PipelineTransform) (modelTransforms []*PipelineTransformModel, err error) {
-	// Loop through all api.Transforms and fetch unwinded transform data
-	if len(apiTransforms) == 0 {
-		return []*PipelineTransformModel{}, nil
-	}
-
-	modelTransforms = make([]*PipelineTransformModel, 0, len(apiTransforms))
-
-	currentTransformID := apiTransforms[0].ID
-	currentTransformTopics := make([]string, 0, len(apiTransforms))
-
+func (r *PipelineResource) api2ModelTransforms(_ context.Context, apiTransforms []*api.PipelineTransform, priorTransforms []*PipelineTransformModel) (modelTransforms []*PipelineTransformModel, err error) {
+	// Group the unwinded api.Transforms by transform, in order
+	modelTransforms = []*PipelineTransformModel{}
+	ids := []string{}
+	names := map[string]string{}
+	topics := map[string][]string{}
 	for _, apiTransform := range apiTransforms {
-		if apiTransform.ID == currentTransformID {
-			currentTransformTopics = append(currentTransformTopics, apiTransform.Topic)
-		} else {
-			modelTransformTopics, diags := types.SetValue(types.StringType, r.strListToTfStrList(currentTransformTopics))
-			if diags.HasError() {
-				return nil, fmt.Errorf("error creating topic set: %s", diags)
-			}
-			modelTransforms = append(modelTransforms, &PipelineTransformModel{
-				ID:     types.StringValue(currentTransformID),
-				Topics: modelTransformTopics,
-			})
-			currentTransformID = apiTransform.ID
-			currentTransformTopics = make([]string, 0, len(apiTransforms))
-			currentTransformTopics = append(currentTransformTopics, apiTransform.Topic)
+		if _, ok := topics[apiTransform.ID]; !ok {
+			ids = append(ids, apiTransform.ID)
+			names[apiTransform.ID] = apiTransform.Name
 		}
+		topics[apiTransform.ID] = append(topics[apiTransform.ID], apiTransform.Topic)
 	}
 
-	modelTransformTopics, diags := types.SetValue(types.StringType, r.strListToTfStrList(currentTransformTopics))
-	if diags.HasError() {
-		return nil, fmt.Errorf("error creating topic set: %s", diags)
+	for _, id := range ids {
+		modelTransformTopics, diags := types.SetValue(types.StringType, r.strListToTfStrList(topics[id]))
+		if diags.HasError() {
+			return nil, fmt.Errorf("error creating topic set: %s", diags)
+		}
+		modelTransform := &PipelineTransformModel{
+			ID:           types.StringValue(id),
+			Name:         types.StringValue(names[id]),
+			Topics:       modelTransformTopics,
+			TopicPattern: types.StringNull(),
+		}
+		// The topic pattern only lives in the configuration, keep it.
+		for _, prior := range priorTransforms {
+			if prior.ID.ValueString() == id {
+				modelTransform.TopicPattern = prior.TopicPattern
+				if names[id] == "" {
+					modelTransform.Name = prior.Name
+				}
+				break
+			}
+		}
+		modelTransforms = append(modelTransforms, modelTransform)
 	}
-	modelTransforms = append(modelTransforms, &PipelineTransformModel{
-		ID:     types.StringValue(currentTransformID),
-		Topics: modelTransformTopics,
-	})
 
 	return modelTransforms, nil
 }
@@ -498,7 +872,7 @@ func (r *PipelineResource) api2Model(ctx context.Context, apiObject api.Pipeline
 		Connector: types.StringValue(apiObject.Destination.Connector),
 	}
 
-	transforms, err := r.api2ModelTransforms(ctx, apiObject.Transforms)
+	transforms, err := r.api2ModelTransforms(ctx, apiObject.Transforms, model.Transforms)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,277 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	res "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/helper"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ res.Resource              = &SourceSnapshotResource{}
+	_ res.ResourceWithConfigure = &SourceSnapshotResource{}
+)
+
+func NewSourceSnapshotResource() res.Resource {
+	return &SourceSnapshotResource{}
+}
+
+// SourceSnapshotResource defines the resource implementation. Creating it
+// fires an incremental snapshot, there is nothing to update or delete.
+type SourceSnapshotResource struct {
+	client api.StreamkapAPI
+}
+
+// SourceSnapshotResourceModel describes the resource data model.
+type SourceSnapshotResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	SourceID          types.String   `tfsdk:"source_id"`
+	Tables            []types.String `tfsdk:"tables"`
+	Triggers          types.Map      `tfsdk:"triggers"`
+	WaitForCompletion types.Bool     `tfsdk:"wait_for_completion"`
+	Status            types.String   `tfsdk:"status"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *SourceSnapshotResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_snapshot"
+}
+
+func (r *SourceSnapshotResource) Schema(ctx context.Context, req res.SchemaRequest, resp *res.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Source snapshot resource. Creating it fires an ad-hoc incremental snapshot of some tables " +
+			"of a source through its signal collection, e.g. to backfill a table added to the source. " +
+			"Change triggers to fire a new snapshot. Destroying it does not stop or undo the snapshot.",
+		MarkdownDescription: "Source snapshot resource. Creating it fires an ad-hoc incremental snapshot of some tables " +
+			"of a source through its signal collection (`signal_data_collection_schema_or_database`), e.g. to backfill " +
+			"a table added to the source. Change `triggers` to fire a new snapshot. Destroying it does not stop or undo the snapshot.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Snapshot identifier",
+				MarkdownDescription: "Snapshot identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "Identifier of the source to snapshot",
+				MarkdownDescription: "Identifier of the source to snapshot",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tables": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				Description:         "Tables or collections to snapshot, e.g. public.users",
+				MarkdownDescription: "Tables or collections to snapshot, e.g. `public.users`",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary map of values that, when changed, fires a new snapshot, " +
+					"like triggers on terraform_data",
+				MarkdownDescription: "Arbitrary map of values that, when changed, fires a new snapshot, " +
+					"like `triggers` on `terraform_data`",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				Description:         "Wait for the snapshot to complete before finishing the apply. Default is false.",
+				MarkdownDescription: "Wait for the snapshot to complete before finishing the apply. Default is `false`.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Snapshot status, e.g. PENDING, RUNNING, COMPLETED or FAILED",
+				MarkdownDescription: "Snapshot status, e.g. `PENDING`, `RUNNING`, `COMPLETED` or `FAILED`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			// Only waiting for the completion takes time
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *SourceSnapshotResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Source Snapshot Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = client
+}
+
+func (r *SourceSnapshotResource) Create(ctx context.Context, req res.CreateRequest, resp *res.CreateResponse) {
+	var plan SourceSnapshotResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	sourceID := plan.SourceID.ValueString()
+	snapshot, err := r.client.TriggerSourceSnapshot(ctx, sourceID, r.model2API(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating source snapshot",
+			fmt.Sprintf("Unable to trigger snapshot of source %s, got error: %s", sourceID, err),
+		)
+		return
+	}
+	r.api2Model(*snapshot, &plan)
+
+	// A snapshot that fails or is not waited for to the end is still saved,
+	// tainted, so that the next apply fires a new one.
+	if plan.WaitForCompletion.ValueBool() {
+		err = helper.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+			snapshot, err := r.client.GetSourceSnapshot(ctx, sourceID, plan.ID.ValueString())
+			if err != nil {
+				return false, err
+			}
+			r.api2Model(*snapshot, &plan)
+			if snapshot.Status == api.SnapshotStatusFailed {
+				return false, fmt.Errorf("snapshot failed: %s", snapshot.Error)
+			}
+			return snapshot.Status == api.SnapshotStatusCompleted, nil
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating source snapshot",
+				fmt.Sprintf("Snapshot %s of source %s did not complete, got error: %s", plan.ID.ValueString(), sourceID, err),
+			)
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *SourceSnapshotResource) Read(ctx context.Context, req res.ReadRequest, resp *res.ReadResponse) {
+	var state SourceSnapshotResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := r.client.GetSourceSnapshot(ctx, state.SourceID.ValueString(), state.ID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		// The snapshot history is not kept forever. A snapshot that is gone
+		// must not be fired again, keep the prior state.
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading source snapshot",
+			fmt.Sprintf("Unable to read source snapshot, got error: %s", err),
+		)
+		return
+	}
+
+	state.Status = types.StringValue(snapshot.Status)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only changes wait_for_completion, every other attribute fires a
+// new snapshot.
+func (r *SourceSnapshotResource) Update(ctx context.Context, req res.UpdateRequest, resp *res.UpdateResponse) {
+	var plan SourceSnapshotResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete only removes the snapshot from the state, a snapshot cannot be
+// undone.
+func (r *SourceSnapshotResource) Delete(ctx context.Context, req res.DeleteRequest, resp *res.DeleteResponse) {
+}
+
+// Helpers
+func (r *SourceSnapshotResource) model2API(model SourceSnapshotResourceModel) api.SourceSnapshot {
+	tables := []string{}
+	for _, t := range model.Tables {
+		tables = append(tables, t.ValueString())
+	}
+
+	return api.SourceSnapshot{
+		Type:   api.SnapshotTypeIncremental,
+		Tables: tables,
+	}
+}
+
+func (r *SourceSnapshotResource) api2Model(apiObject api.SourceSnapshot, model *SourceSnapshotResourceModel) {
+	// Copy the API Object to the model, the tables are kept as planned
+	model.ID = types.StringValue(apiObject.ID)
+	model.Status = types.StringValue(apiObject.Status)
+}
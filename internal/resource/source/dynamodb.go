@@ -2,9 +2,12 @@ package source
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -39,24 +42,28 @@ type SourceDynamoDBResource struct {
 
 // SourceDynamoDBResourceModel describes the resource data model.
 type SourceDynamoDBResourceModel struct {
-	ID                            types.String `tfsdk:"id"`
-	Name                          types.String `tfsdk:"name"`
-	Connector                     types.String `tfsdk:"connector"`
-	AWSRegion                     types.String `tfsdk:"aws_region"`
-	AWSAccessKeyID                types.String `tfsdk:"aws_access_key_id"`
-	AWSSecretKey                  types.String `tfsdk:"aws_secret_key"`
-	S3ExportBucketName            types.String `tfsdk:"s3_export_bucket_name"`
-	TableIncludeListUserDefined   types.String `tfsdk:"table_include_list_user_defined"`
-	BatchSize                     types.Int64  `tfsdk:"batch_size"`
-	DynamoDBServiceEndpoint       types.String `tfsdk:"dynamodb_service_endpoint"`
-	PollTimeoutMS                 types.Int64  `tfsdk:"poll_timeout_ms"`
-	IncrementalSnapshotChunkSize  types.Int64  `tfsdk:"incremental_snapshot_chunk_size"`
-	IncrementalSnapshotMaxThreads types.Int64  `tfsdk:"incremental_snapshot_max_threads"`
-	FullExportExpirationTimeMS    types.Int64  `tfsdk:"full_export_expiration_time_ms"`
-	SignalKafkaPollTimeoutMS      types.Int64  `tfsdk:"signal_kafka_poll_timeout_ms"`
-	ArrayEncodingJson             types.Bool   `tfsdk:"array_encoding_json"`
-	StructEncodingJson            types.Bool   `tfsdk:"struct_encoding_json"`
-	TasksMax                      types.Int64  `tfsdk:"tasks_max"`
+	ID                            types.String   `tfsdk:"id"`
+	Name                          types.String   `tfsdk:"name"`
+	Connector                     types.String   `tfsdk:"connector"`
+	AWSRegion                     types.String   `tfsdk:"aws_region"`
+	AWSAccessKeyID                types.String   `tfsdk:"aws_access_key_id"`
+	AWSSecretKey                  types.String   `tfsdk:"aws_secret_key"`
+	AWSSecretKeyWO                types.String   `tfsdk:"aws_secret_key_wo"`
+	AWSSecretKeyWOVersion         types.Int64    `tfsdk:"aws_secret_key_wo_version"`
+	S3ExportBucketName            types.String   `tfsdk:"s3_export_bucket_name"`
+	TableIncludeListUserDefined   types.String   `tfsdk:"table_include_list_user_defined"`
+	BatchSize                     types.Int64    `tfsdk:"batch_size"`
+	DynamoDBServiceEndpoint       types.String   `tfsdk:"dynamodb_service_endpoint"`
+	PollTimeoutMS                 types.Int64    `tfsdk:"poll_timeout_ms"`
+	IncrementalSnapshotChunkSize  types.Int64    `tfsdk:"incremental_snapshot_chunk_size"`
+	IncrementalSnapshotMaxThreads types.Int64    `tfsdk:"incremental_snapshot_max_threads"`
+	FullExportExpirationTimeMS    types.Int64    `tfsdk:"full_export_expiration_time_ms"`
+	SignalKafkaPollTimeoutMS      types.Int64    `tfsdk:"signal_kafka_poll_timeout_ms"`
+	ArrayEncodingJson             types.Bool     `tfsdk:"array_encoding_json"`
+	StructEncodingJson            types.Bool     `tfsdk:"struct_encoding_json"`
+	TasksMax                      types.Int64    `tfsdk:"tasks_max"`
+	WaitForRunning                types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts                      timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SourceDynamoDBResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -89,8 +96,8 @@ func (r *SourceDynamoDBResource) Schema(ctx context.Context, req res.SchemaReque
 			},
 			"aws_region": schema.StringAttribute{
 				Required:            true,
-				Description:         "AWS Region",
-				MarkdownDescription: "AWS Region",
+				Description:         "AWS Region. Changing it forces a new resource to be created.",
+				MarkdownDescription: "AWS Region. Changing it forces a new resource to be created.",
 			},
 			"aws_access_key_id": schema.StringAttribute{
 				Required:            true,
@@ -98,11 +105,16 @@ func (r *SourceDynamoDBResource) Schema(ctx context.Context, req res.SchemaReque
 				MarkdownDescription: "AWS Access Key ID",
 			},
 			"aws_secret_key": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "AWS Secret Key",
-				MarkdownDescription: "AWS Secret Key",
+				Description:         "AWS Secret Key. Exactly one of aws_secret_key or aws_secret_key_wo must be set.",
+				MarkdownDescription: "AWS Secret Key. Exactly one of `aws_secret_key` or `aws_secret_key_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("aws_secret_key_wo")),
+				},
 			},
+			"aws_secret_key_wo":         helper.WriteOnlyAttribute("aws_secret_key"),
+			"aws_secret_key_wo_version": helper.WriteOnlyVersionAttribute("aws_secret_key"),
 			"s3_export_bucket_name": schema.StringAttribute{
 				Required:            true,
 				Description:         "used for backfill (snapshot)",
@@ -184,8 +196,14 @@ func (r *SourceDynamoDBResource) Schema(ctx context.Context, req res.SchemaReque
 					int64validator.Between(1, 40),
 				},
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableSourceAttributes[r.connector_code])
 }
 
 func (r *SourceDynamoDBResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -211,12 +229,24 @@ func (r *SourceDynamoDBResource) Create(ctx context.Context, req res.CreateReque
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("aws_secret_key_wo"), &plan.AWSSecretKeyWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	config := r.model2ConfigMap(plan)
 
 	source, err := r.client.CreateSource(ctx, api.Source{
@@ -238,6 +268,17 @@ func (r *SourceDynamoDBResource) Create(ctx context.Context, req res.CreateReque
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	// A source that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating DynamoDB source",
+				fmt.Sprintf("Unable to create DynamoDB source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -250,6 +291,8 @@ func (r *SourceDynamoDBResource) Read(ctx context.Context, req res.ReadRequest,
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -257,6 +300,10 @@ func (r *SourceDynamoDBResource) Read(ctx context.Context, req res.ReadRequest,
 
 	sourceID := state.ID.ValueString()
 	source, err := r.client.GetSource(ctx, sourceID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading DynamoDB source",
@@ -264,14 +311,15 @@ func (r *SourceDynamoDBResource) Read(ctx context.Context, req res.ReadRequest,
 		)
 		return
 	}
-	if source == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(source.Name)
 	state.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &state)
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -284,9 +332,21 @@ func (r *SourceDynamoDBResource) Update(ctx context.Context, req res.UpdateReque
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("aws_secret_key_wo"), &plan.AWSSecretKeyWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(plan)
@@ -310,6 +370,16 @@ func (r *SourceDynamoDBResource) Update(ctx context.Context, req res.UpdateReque
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating DynamoDB source",
+				fmt.Sprintf("Unable to update DynamoDB source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -322,10 +392,20 @@ func (r *SourceDynamoDBResource) Delete(ctx context.Context, req res.DeleteReque
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeleteSource(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -337,7 +417,7 @@ func (r *SourceDynamoDBResource) Delete(ctx context.Context, req res.DeleteReque
 }
 
 func (r *SourceDynamoDBResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportSourceState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -345,7 +425,7 @@ func (r *SourceDynamoDBResource) model2ConfigMap(model SourceDynamoDBResourceMod
 	return map[string]any{
 		"aws.region":                       model.AWSRegion.ValueString(),
 		"aws.access.key.id":                model.AWSAccessKeyID.ValueString(),
-		"aws.secret.key":                   model.AWSSecretKey.ValueString(),
+		"aws.secret.key":                   helper.PreferWriteOnly(model.AWSSecretKeyWO, model.AWSSecretKey).ValueString(),
 		"s3.export.bucket.name":            model.S3ExportBucketName.ValueString(),
 		"table.include.list.user.defined":  model.TableIncludeListUserDefined.ValueString(),
 		"batch.size":                       int(model.BatchSize.ValueInt64()),
@@ -365,7 +445,10 @@ func (r *SourceDynamoDBResource) configMap2Model(cfg map[string]any, model *Sour
 	// Copy the config map to the model
 	model.AWSRegion = helper.GetTfCfgString(cfg, "aws.region")
 	model.AWSAccessKeyID = helper.GetTfCfgString(cfg, "aws.access.key.id")
-	model.AWSSecretKey = helper.GetTfCfgString(cfg, "aws.secret.key")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.AWSSecretKey = helper.GetTfCfgString(cfg, "aws.secret.key")
+	}
 	model.S3ExportBucketName = helper.GetTfCfgString(cfg, "s3.export.bucket.name")
 	model.TableIncludeListUserDefined = helper.GetTfCfgString(cfg, "table.include.list.user.defined")
 	model.BatchSize = helper.GetTfCfgInt64(cfg, "batch.size")
@@ -2,10 +2,11 @@ package source
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -39,13 +40,15 @@ type SourceKafkaDirectResource struct {
 
 // SourceKafkaDirectResourceModel describes the resource data model.
 type SourceKafkaDirectResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	Connector        types.String `tfsdk:"connector"`
-	TopicPrefix      types.String `tfsdk:"topic_prefix"`
-	KafkaFormat      types.String `tfsdk:"kafka_format"`
-	SchemasEnable    types.Bool   `tfsdk:"schemas_enable"`
-	TopicIncludeList types.String `tfsdk:"topic_include_list"`
+	ID               types.String   `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	Connector        types.String   `tfsdk:"connector"`
+	TopicPrefix      types.String   `tfsdk:"topic_prefix"`
+	KafkaFormat      types.String   `tfsdk:"kafka_format"`
+	SchemasEnable    types.Bool     `tfsdk:"schemas_enable"`
+	TopicIncludeList types.String   `tfsdk:"topic_include_list"`
+	WaitForRunning   types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SourceKafkaDirectResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -78,8 +81,8 @@ func (r *SourceKafkaDirectResource) Schema(ctx context.Context, req res.SchemaRe
 			},
 			"topic_prefix": schema.StringAttribute{
 				Required:            true,
-				Description:         "Prefix for the topic",
-				MarkdownDescription: "Prefix for the topic",
+				Description:         "Prefix for the topic. Changing it forces a new resource to be created.",
+				MarkdownDescription: "Prefix for the topic. Changing it forces a new resource to be created.",
 			},
 			"kafka_format": schema.StringAttribute{
 				Computed:            true,
@@ -107,8 +110,14 @@ func (r *SourceKafkaDirectResource) Schema(ctx context.Context, req res.SchemaRe
 				Description:         "Topics to sync",
 				MarkdownDescription: "Topics to sync",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableSourceAttributes[r.connector_code])
 }
 
 func (r *SourceKafkaDirectResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -134,12 +143,21 @@ func (r *SourceKafkaDirectResource) Create(ctx context.Context, req res.CreateRe
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	config := r.model2ConfigMap(plan)
 
 	source, err := r.client.CreateSource(ctx, api.Source{
@@ -161,6 +179,17 @@ func (r *SourceKafkaDirectResource) Create(ctx context.Context, req res.CreateRe
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	// A source that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating Kafka Direct source",
+				fmt.Sprintf("Unable to create Kafka Direct source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -173,6 +202,7 @@ func (r *SourceKafkaDirectResource) Read(ctx context.Context, req res.ReadReques
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -180,6 +210,10 @@ func (r *SourceKafkaDirectResource) Read(ctx context.Context, req res.ReadReques
 
 	sourceID := state.ID.ValueString()
 	source, err := r.client.GetSource(ctx, sourceID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading Kafka Direct source",
@@ -187,14 +221,15 @@ func (r *SourceKafkaDirectResource) Read(ctx context.Context, req res.ReadReques
 		)
 		return
 	}
-	if source == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(source.Name)
 	state.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &state)
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -207,9 +242,18 @@ func (r *SourceKafkaDirectResource) Update(ctx context.Context, req res.UpdateRe
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(plan)
@@ -233,6 +277,16 @@ func (r *SourceKafkaDirectResource) Update(ctx context.Context, req res.UpdateRe
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Kafka Direct source",
+				fmt.Sprintf("Unable to update Kafka Direct source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -245,9 +299,18 @@ func (r *SourceKafkaDirectResource) Delete(ctx context.Context, req res.DeleteRe
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	err := r.client.DeleteSource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -260,7 +323,7 @@ func (r *SourceKafkaDirectResource) Delete(ctx context.Context, req res.DeleteRe
 }
 
 func (r *SourceKafkaDirectResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportSourceState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
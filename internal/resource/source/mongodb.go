@@ -2,8 +2,10 @@ package source
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,9 +24,10 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ res.Resource                = &SourceMongoDBResource{}
-	_ res.ResourceWithConfigure   = &SourceMongoDBResource{}
-	_ res.ResourceWithImportState = &SourceMongoDBResource{}
+	_ res.Resource                     = &SourceMongoDBResource{}
+	_ res.ResourceWithConfigure        = &SourceMongoDBResource{}
+	_ res.ResourceWithConfigValidators = &SourceMongoDBResource{}
+	_ res.ResourceWithImportState      = &SourceMongoDBResource{}
 )
 
 func NewSourceMongoDBResource() res.Resource {
@@ -39,28 +42,32 @@ type SourceMongoDBResource struct {
 
 // SourceMongoDBResourceModel describes the resource data model.
 type SourceMongoDBResourceModel struct {
-	ID                                   types.String `tfsdk:"id"`
-	Name                                 types.String `tfsdk:"name"`
-	Connector                            types.String `tfsdk:"connector"`
-	MongoDBConnectionString              types.String `tfsdk:"mongodb_connection_string"`
-	ArrayEncoding                        types.String `tfsdk:"array_encoding"`
-	NestedDocumentEncoding               types.String `tfsdk:"nested_document_encoding"`
-	DatabaseIncludeList                  types.String `tfsdk:"database_include_list"`
-	CollectionIncludeList                types.String `tfsdk:"collection_include_list"`
-	SignalDataCollectionSchemaOrDatabase types.String `tfsdk:"signal_data_collection_schema_or_database"`
-	SSHEnabled                           types.Bool   `tfsdk:"ssh_enabled"`
-	SSHHost                              types.String `tfsdk:"ssh_host"`
-	SSHPort                              types.String `tfsdk:"ssh_port"`
-	SSHUser                              types.String `tfsdk:"ssh_user"`
-	PredicatesIsTopicToEnrichPattern     types.String `tfsdk:"predicates_istopictoenrich_pattern"`
-	InsertStaticKeyField1                   types.String `tfsdk:"insert_static_key_field_1"`
-	InsertStaticKeyValue1                   types.String `tfsdk:"insert_static_key_value_1"`
-	InsertStaticValueField1                 types.String `tfsdk:"insert_static_value_field_1"`
-	InsertStaticValue1                      types.String `tfsdk:"insert_static_value_1"`
-	InsertStaticKeyField2                   types.String `tfsdk:"insert_static_key_field_2"`
-	InsertStaticKeyValue2                   types.String `tfsdk:"insert_static_key_value_2"`
-	InsertStaticValueField2                 types.String `tfsdk:"insert_static_value_field_2"`
-	InsertStaticValue2                      types.String `tfsdk:"insert_static_value_2"`
+	ID                                   types.String   `tfsdk:"id"`
+	Name                                 types.String   `tfsdk:"name"`
+	Connector                            types.String   `tfsdk:"connector"`
+	MongoDBConnectionString              types.String   `tfsdk:"mongodb_connection_string"`
+	MongoDBConnectionStringWO            types.String   `tfsdk:"mongodb_connection_string_wo"`
+	MongoDBConnectionStringWOVersion     types.Int64    `tfsdk:"mongodb_connection_string_wo_version"`
+	ArrayEncoding                        types.String   `tfsdk:"array_encoding"`
+	NestedDocumentEncoding               types.String   `tfsdk:"nested_document_encoding"`
+	DatabaseIncludeList                  types.String   `tfsdk:"database_include_list"`
+	CollectionIncludeList                types.String   `tfsdk:"collection_include_list"`
+	SignalDataCollectionSchemaOrDatabase types.String   `tfsdk:"signal_data_collection_schema_or_database"`
+	SSHEnabled                           types.Bool     `tfsdk:"ssh_enabled"`
+	SSHHost                              types.String   `tfsdk:"ssh_host"`
+	SSHPort                              types.String   `tfsdk:"ssh_port"`
+	SSHUser                              types.String   `tfsdk:"ssh_user"`
+	PredicatesIsTopicToEnrichPattern     types.String   `tfsdk:"predicates_istopictoenrich_pattern"`
+	InsertStaticKeyField1                types.String   `tfsdk:"insert_static_key_field_1"`
+	InsertStaticKeyValue1                types.String   `tfsdk:"insert_static_key_value_1"`
+	InsertStaticValueField1              types.String   `tfsdk:"insert_static_value_field_1"`
+	InsertStaticValue1                   types.String   `tfsdk:"insert_static_value_1"`
+	InsertStaticKeyField2                types.String   `tfsdk:"insert_static_key_field_2"`
+	InsertStaticKeyValue2                types.String   `tfsdk:"insert_static_key_value_2"`
+	InsertStaticValueField2              types.String   `tfsdk:"insert_static_value_field_2"`
+	InsertStaticValue2                   types.String   `tfsdk:"insert_static_value_2"`
+	WaitForRunning                       types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts                             timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SourceMongoDBResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -92,11 +99,16 @@ func (r *SourceMongoDBResource) Schema(ctx context.Context, req res.SchemaReques
 				},
 			},
 			"mongodb_connection_string": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "Mongodb Connection String. See Mongodb documentation for further details.",
-				MarkdownDescription: "Mongodb Connection String. See Mongodb documentation for further details.",
+				Description:         "Mongodb Connection String. See Mongodb documentation for further details. Exactly one of mongodb_connection_string or mongodb_connection_string_wo must be set.",
+				MarkdownDescription: "Mongodb Connection String. See Mongodb documentation for further details. Exactly one of `mongodb_connection_string` or `mongodb_connection_string_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("mongodb_connection_string_wo")),
+				},
 			},
+			"mongodb_connection_string_wo":         helper.WriteOnlyAttribute("mongodb_connection_string"),
+			"mongodb_connection_string_wo_version": helper.WriteOnlyVersionAttribute("mongodb_connection_string"),
 			"array_encoding": schema.StringAttribute{
 				Computed:            true,
 				Optional:            true,
@@ -211,8 +223,22 @@ func (r *SourceMongoDBResource) Schema(ctx context.Context, req res.SchemaReques
 				Description:         "The value of the static field to be added to the message value.",
 				MarkdownDescription: "The value of the static field to be added to the message value.",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableSourceAttributes[r.connector_code])
+}
+
+// ConfigValidators reports conflicting options at plan time rather than
+// during the apply.
+func (r *SourceMongoDBResource) ConfigValidators(ctx context.Context) []res.ConfigValidator {
+	return []res.ConfigValidator{
+		helper.RequiredWhenTrue("ssh_enabled", "ssh_host"),
+	}
 }
 
 func (r *SourceMongoDBResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -238,12 +264,24 @@ func (r *SourceMongoDBResource) Create(ctx context.Context, req res.CreateReques
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("mongodb_connection_string_wo"), &plan.MongoDBConnectionStringWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	config := r.model2ConfigMap(plan)
 
 	source, err := r.client.CreateSource(ctx, api.Source{
@@ -265,6 +303,17 @@ func (r *SourceMongoDBResource) Create(ctx context.Context, req res.CreateReques
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	// A source that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating MongoDB source",
+				fmt.Sprintf("Unable to create MongoDB source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -277,6 +326,8 @@ func (r *SourceMongoDBResource) Read(ctx context.Context, req res.ReadRequest, r
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -284,6 +335,10 @@ func (r *SourceMongoDBResource) Read(ctx context.Context, req res.ReadRequest, r
 
 	sourceID := state.ID.ValueString()
 	source, err := r.client.GetSource(ctx, sourceID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading MongoDB source",
@@ -291,14 +346,15 @@ func (r *SourceMongoDBResource) Read(ctx context.Context, req res.ReadRequest, r
 		)
 		return
 	}
-	if source == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(source.Name)
 	state.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &state)
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -311,10 +367,22 @@ func (r *SourceMongoDBResource) Update(ctx context.Context, req res.UpdateReques
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("mongodb_connection_string_wo"), &plan.MongoDBConnectionStringWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", plan))
 	config := r.model2ConfigMap(plan)
 
@@ -337,6 +405,16 @@ func (r *SourceMongoDBResource) Update(ctx context.Context, req res.UpdateReques
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating MongoDB source",
+				fmt.Sprintf("Unable to update MongoDB source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -349,9 +427,19 @@ func (r *SourceMongoDBResource) Delete(ctx context.Context, req res.DeleteReques
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	err := r.client.DeleteSource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -364,13 +452,13 @@ func (r *SourceMongoDBResource) Delete(ctx context.Context, req res.DeleteReques
 }
 
 func (r *SourceMongoDBResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportSourceState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
 func (r *SourceMongoDBResource) model2ConfigMap(model SourceMongoDBResourceModel) map[string]any {
 	return map[string]any{
-		"mongodb.connection.string.user.defined":    model.MongoDBConnectionString.ValueString(),
+		"mongodb.connection.string.user.defined":    helper.PreferWriteOnly(model.MongoDBConnectionStringWO, model.MongoDBConnectionString).ValueString(),
 		"transforms.unwrap.array.encoding":          model.ArrayEncoding.ValueString(),
 		"transforms.unwrap.document.encoding":       model.NestedDocumentEncoding.ValueString(),
 		"database.include.list":                     model.DatabaseIncludeList.ValueString(),
@@ -394,7 +482,10 @@ func (r *SourceMongoDBResource) model2ConfigMap(model SourceMongoDBResourceModel
 
 func (r *SourceMongoDBResource) configMap2Model(cfg map[string]any, model *SourceMongoDBResourceModel) {
 	// Copy the config map to the model
-	model.MongoDBConnectionString = helper.GetTfCfgString(cfg, "mongodb.connection.string.user.defined")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.MongoDBConnectionString = helper.GetTfCfgString(cfg, "mongodb.connection.string.user.defined")
+	}
 	model.ArrayEncoding = helper.GetTfCfgString(cfg, "transforms.unwrap.array.encoding")
 	model.NestedDocumentEncoding = helper.GetTfCfgString(cfg, "transforms.unwrap.document.encoding")
 	model.DatabaseIncludeList = helper.GetTfCfgString(cfg, "database.include.list")
@@ -2,8 +2,11 @@ package source
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,9 +26,10 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ res.Resource                = &SourcePostgreSQLResource{}
-	_ res.ResourceWithConfigure   = &SourcePostgreSQLResource{}
-	_ res.ResourceWithImportState = &SourcePostgreSQLResource{}
+	_ res.Resource                     = &SourcePostgreSQLResource{}
+	_ res.ResourceWithConfigure        = &SourcePostgreSQLResource{}
+	_ res.ResourceWithConfigValidators = &SourcePostgreSQLResource{}
+	_ res.ResourceWithImportState      = &SourcePostgreSQLResource{}
 )
 
 func NewSourcePostgreSQLResource() res.Resource {
@@ -40,41 +44,45 @@ type SourcePostgreSQLResource struct {
 
 // SourcePostgreSQLResourceModel describes the resource data model.
 type SourcePostgreSQLResourceModel struct {
-	ID                                      types.String `tfsdk:"id"`
-	Name                                    types.String `tfsdk:"name"`
-	Connector                               types.String `tfsdk:"connector"`
-	DatabaseHostname                        types.String `tfsdk:"database_hostname"`
-	DatabasePort                            types.Int64  `tfsdk:"database_port"`
-	DatabaseUser                            types.String `tfsdk:"database_user"`
-	DatabasePassword                        types.String `tfsdk:"database_password"`
-	DatabaseDbname                          types.String `tfsdk:"database_dbname"`
-	SnapshotReadOnly                        types.String `tfsdk:"snapshot_read_only"`
-	DatabaseSSLMode                         types.String `tfsdk:"database_sslmode"`
-	SchemaIncludeList                       types.String `tfsdk:"schema_include_list"`
-	TableIncludeList                        types.String `tfsdk:"table_include_list"`
-	SignalDataCollectionSchemaOrDatabase    types.String `tfsdk:"signal_data_collection_schema_or_database"`
-	ColumnIncludeList                       types.String `tfsdk:"column_include_list"`
-	ColumnExcludeList                       types.String `tfsdk:"column_exclude_list"`
-	HeartbeatEnabled                        types.Bool   `tfsdk:"heartbeat_enabled"`
-	HeartbeatDataCollectionSchemaOrDatabase types.String `tfsdk:"heartbeat_data_collection_schema_or_database"`
-	HeartbeatUseLogicalMessage              types.Bool   `tfsdk:"heartbeat_use_logical_message"`
-	IncludeSourceDBNameInTableName          types.Bool   `tfsdk:"include_source_db_name_in_table_name"`
-	SlotName                                types.String `tfsdk:"slot_name"`
-	PublicationName                         types.String `tfsdk:"publication_name"`
-	BinaryHandlingMode                      types.String `tfsdk:"binary_handling_mode"`
-	SSHEnabled                              types.Bool   `tfsdk:"ssh_enabled"`
-	SSHHost                                 types.String `tfsdk:"ssh_host"`
-	SSHPort                                 types.String `tfsdk:"ssh_port"`
-	SSHUser                                 types.String `tfsdk:"ssh_user"`
-	PredicatesIsTopicToEnrichPattern        types.String `tfsdk:"predicates_istopictoenrich_pattern"`
-	InsertStaticKeyField1                   types.String `tfsdk:"insert_static_key_field_1"`
-	InsertStaticKeyValue1                   types.String `tfsdk:"insert_static_key_value_1"`
-	InsertStaticValueField1                 types.String `tfsdk:"insert_static_value_field_1"`
-	InsertStaticValue1                      types.String `tfsdk:"insert_static_value_1"`
-	InsertStaticKeyField2                   types.String `tfsdk:"insert_static_key_field_2"`
-	InsertStaticKeyValue2                   types.String `tfsdk:"insert_static_key_value_2"`
-	InsertStaticValueField2                 types.String `tfsdk:"insert_static_value_field_2"`
-	InsertStaticValue2                      types.String `tfsdk:"insert_static_value_2"`
+	ID                                      types.String   `tfsdk:"id"`
+	Name                                    types.String   `tfsdk:"name"`
+	Connector                               types.String   `tfsdk:"connector"`
+	DatabaseHostname                        types.String   `tfsdk:"database_hostname"`
+	DatabasePort                            types.Int64    `tfsdk:"database_port"`
+	DatabaseUser                            types.String   `tfsdk:"database_user"`
+	DatabasePassword                        types.String   `tfsdk:"database_password"`
+	DatabasePasswordWO                      types.String   `tfsdk:"database_password_wo"`
+	DatabasePasswordWOVersion               types.Int64    `tfsdk:"database_password_wo_version"`
+	DatabaseDbname                          types.String   `tfsdk:"database_dbname"`
+	SnapshotReadOnly                        types.String   `tfsdk:"snapshot_read_only"`
+	DatabaseSSLMode                         types.String   `tfsdk:"database_sslmode"`
+	SchemaIncludeList                       types.String   `tfsdk:"schema_include_list"`
+	TableIncludeList                        types.String   `tfsdk:"table_include_list"`
+	SignalDataCollectionSchemaOrDatabase    types.String   `tfsdk:"signal_data_collection_schema_or_database"`
+	ColumnIncludeList                       types.String   `tfsdk:"column_include_list"`
+	ColumnExcludeList                       types.String   `tfsdk:"column_exclude_list"`
+	HeartbeatEnabled                        types.Bool     `tfsdk:"heartbeat_enabled"`
+	HeartbeatDataCollectionSchemaOrDatabase types.String   `tfsdk:"heartbeat_data_collection_schema_or_database"`
+	HeartbeatUseLogicalMessage              types.Bool     `tfsdk:"heartbeat_use_logical_message"`
+	IncludeSourceDBNameInTableName          types.Bool     `tfsdk:"include_source_db_name_in_table_name"`
+	SlotName                                types.String   `tfsdk:"slot_name"`
+	PublicationName                         types.String   `tfsdk:"publication_name"`
+	BinaryHandlingMode                      types.String   `tfsdk:"binary_handling_mode"`
+	SSHEnabled                              types.Bool     `tfsdk:"ssh_enabled"`
+	SSHHost                                 types.String   `tfsdk:"ssh_host"`
+	SSHPort                                 types.String   `tfsdk:"ssh_port"`
+	SSHUser                                 types.String   `tfsdk:"ssh_user"`
+	PredicatesIsTopicToEnrichPattern        types.String   `tfsdk:"predicates_istopictoenrich_pattern"`
+	InsertStaticKeyField1                   types.String   `tfsdk:"insert_static_key_field_1"`
+	InsertStaticKeyValue1                   types.String   `tfsdk:"insert_static_key_value_1"`
+	InsertStaticValueField1                 types.String   `tfsdk:"insert_static_value_field_1"`
+	InsertStaticValue1                      types.String   `tfsdk:"insert_static_value_1"`
+	InsertStaticKeyField2                   types.String   `tfsdk:"insert_static_key_field_2"`
+	InsertStaticKeyValue2                   types.String   `tfsdk:"insert_static_key_value_2"`
+	InsertStaticValueField2                 types.String   `tfsdk:"insert_static_value_field_2"`
+	InsertStaticValue2                      types.String   `tfsdk:"insert_static_value_2"`
+	WaitForRunning                          types.Bool     `tfsdk:"wait_for_running"`
+	Timeouts                                timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SourcePostgreSQLResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -123,15 +131,20 @@ func (r *SourcePostgreSQLResource) Schema(ctx context.Context, req res.SchemaReq
 				MarkdownDescription: "Username to access the database",
 			},
 			"database_password": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "Password to access the database",
-				MarkdownDescription: "Password to access the database",
+				Description:         "Password to access the database. Exactly one of database_password or database_password_wo must be set.",
+				MarkdownDescription: "Password to access the database. Exactly one of `database_password` or `database_password_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("database_password_wo")),
+				},
 			},
+			"database_password_wo":         helper.WriteOnlyAttribute("database_password"),
+			"database_password_wo_version": helper.WriteOnlyVersionAttribute("database_password"),
 			"database_dbname": schema.StringAttribute{
 				Required:            true,
-				Description:         "Database from which to stream data",
-				MarkdownDescription: "Database from which to stream data",
+				Description:         "Database from which to stream data. Changing it forces a new resource to be created.",
+				MarkdownDescription: "Database from which to stream data. Changing it forces a new resource to be created.",
 			},
 			"snapshot_read_only": schema.StringAttribute{
 				Optional: true,
@@ -202,19 +215,19 @@ func (r *SourcePostgreSQLResource) Schema(ctx context.Context, req res.SchemaReq
 					"polling and committing offsets on low-traffic sources. " +
 					"Set heartbeat_data_collection_schema_or_database to also write to a streamkap_heartbeat " +
 					"table in the source database; leave it null for Kafka-only mode. " +
-					"When false, neither heartbeat path runs and heartbeat_data_collection_schema_or_database is ignored.",
+					"When false, neither heartbeat path runs and heartbeat_data_collection_schema_or_database must be left null.",
 				MarkdownDescription: "When `true`, emit a periodic heartbeat to a Kafka topic so the connector keeps " +
 					"polling and committing offsets on low-traffic sources. " +
 					"Set `heartbeat_data_collection_schema_or_database` to also write to a `streamkap_heartbeat` " +
 					"table in the source database; leave it `null` for Kafka-only mode. " +
-					"When `false`, neither heartbeat path runs and `heartbeat_data_collection_schema_or_database` is ignored.",
+					"When `false`, neither heartbeat path runs and `heartbeat_data_collection_schema_or_database` must be left `null`.",
 			},
 			"heartbeat_data_collection_schema_or_database": schema.StringAttribute{
 				Optional: true,
-				Description: "Optional. Only takes effect when heartbeat_enabled is true. Schema containing a streamkap_heartbeat " +
+				Description: "Optional. Can only be set when heartbeat_enabled is true. Schema containing a streamkap_heartbeat " +
 					"table — providing this enables source-table heartbeat mode, which writes to the table on each beat to keep the " +
 					"source transaction log active. Leave null for Kafka-only heartbeat (no table or write grant required).",
-				MarkdownDescription: "Optional. Only takes effect when `heartbeat_enabled` is `true`. Schema containing a `streamkap_heartbeat` " +
+				MarkdownDescription: "Optional. Can only be set when `heartbeat_enabled` is `true`. Schema containing a `streamkap_heartbeat` " +
 					"table — providing this enables source-table heartbeat mode, which writes to the table on each beat to keep the " +
 					"source transaction log active. Leave `null` for Kafka-only heartbeat (no table or write grant required).",
 			},
@@ -244,15 +257,15 @@ func (r *SourcePostgreSQLResource) Schema(ctx context.Context, req res.SchemaReq
 				Computed:            true,
 				Optional:            true,
 				Default:             stringdefault.StaticString("streamkap_pgoutput_slot"),
-				Description:         "Replication slot name for the connector",
-				MarkdownDescription: "Replication slot name for the connector",
+				Description:         "Replication slot name for the connector. Changing it forces a new resource to be created.",
+				MarkdownDescription: "Replication slot name for the connector. Changing it forces a new resource to be created.",
 			},
 			"publication_name": schema.StringAttribute{
 				Computed:            true,
 				Optional:            true,
 				Default:             stringdefault.StaticString("streamkap_pub"),
-				Description:         "Publication name for the connector",
-				MarkdownDescription: "Publication name for the connector",
+				Description:         "Publication name for the connector. Changing it forces a new resource to be created.",
+				MarkdownDescription: "Publication name for the connector. Changing it forces a new resource to be created.",
 			},
 			"binary_handling_mode": schema.StringAttribute{
 				Computed:            true,
@@ -342,8 +355,27 @@ func (r *SourcePostgreSQLResource) Schema(ctx context.Context, req res.SchemaReq
 				Description:         "The value of the static field to be added to the message value.",
 				MarkdownDescription: "The value of the static field to be added to the message value.",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableSourceAttributes[r.connector_code])
+}
+
+// ConfigValidators reports conflicting options at plan time rather than
+// during the apply.
+func (r *SourcePostgreSQLResource) ConfigValidators(ctx context.Context) []res.ConfigValidator {
+	return []res.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("column_include_list"),
+			path.MatchRoot("column_exclude_list"),
+		),
+		helper.RequiredWhenTrue("ssh_enabled", "ssh_host"),
+		helper.OnlyWhenTrue("heartbeat_enabled", "heartbeat_data_collection_schema_or_database"),
+	}
 }
 
 func (r *SourcePostgreSQLResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -369,12 +401,24 @@ func (r *SourcePostgreSQLResource) Create(ctx context.Context, req res.CreateReq
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("database_password_wo"), &plan.DatabasePasswordWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	config, err := r.model2ConfigMap(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -403,6 +447,17 @@ func (r *SourcePostgreSQLResource) Create(ctx context.Context, req res.CreateReq
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	// A source that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating PostgreSQL source",
+				fmt.Sprintf("Unable to create PostgreSQL source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -415,6 +470,8 @@ func (r *SourcePostgreSQLResource) Read(ctx context.Context, req res.ReadRequest
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -422,6 +479,10 @@ func (r *SourcePostgreSQLResource) Read(ctx context.Context, req res.ReadRequest
 
 	sourceID := state.ID.ValueString()
 	source, err := r.client.GetSource(ctx, sourceID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading PostgreSQL source",
@@ -429,14 +490,15 @@ func (r *SourcePostgreSQLResource) Read(ctx context.Context, req res.ReadRequest
 		)
 		return
 	}
-	if source == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(source.Name)
 	state.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &state)
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -449,10 +511,22 @@ func (r *SourcePostgreSQLResource) Update(ctx context.Context, req res.UpdateReq
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("database_password_wo"), &plan.DatabasePasswordWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", plan))
 	config, err := r.model2ConfigMap(plan)
 	if err != nil {
@@ -482,6 +556,16 @@ func (r *SourcePostgreSQLResource) Update(ctx context.Context, req res.UpdateReq
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating PostgreSQL source",
+				fmt.Sprintf("Unable to update PostgreSQL source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -494,9 +578,19 @@ func (r *SourcePostgreSQLResource) Delete(ctx context.Context, req res.DeleteReq
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	err := r.client.DeleteSource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -509,7 +603,7 @@ func (r *SourcePostgreSQLResource) Delete(ctx context.Context, req res.DeleteReq
 }
 
 func (r *SourcePostgreSQLResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportSourceState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -522,7 +616,7 @@ func (r *SourcePostgreSQLResource) model2ConfigMap(model SourcePostgreSQLResourc
 		"database.hostname.user.defined":                    model.DatabaseHostname.ValueString(),
 		"database.port.user.defined":                        int(model.DatabasePort.ValueInt64()),
 		"database.user":                                     model.DatabaseUser.ValueString(),
-		"database.password":                                 model.DatabasePassword.ValueString(),
+		"database.password":                                 helper.PreferWriteOnly(model.DatabasePasswordWO, model.DatabasePassword).ValueString(),
 		"database.dbname":                                   model.DatabaseDbname.ValueString(),
 		"snapshot.read.only.user.defined":                   model.SnapshotReadOnly.ValueString(),
 		"database.sslmode":                                  model.DatabaseSSLMode.ValueString(),
@@ -568,7 +662,10 @@ func (r *SourcePostgreSQLResource) configMap2Model(cfg map[string]any, model *So
 	model.DatabaseHostname = helper.GetTfCfgString(cfg, "database.hostname.user.defined")
 	model.DatabasePort = helper.GetTfCfgInt64(cfg, "database.port.user.defined")
 	model.DatabaseUser = helper.GetTfCfgString(cfg, "database.user")
-	model.DatabasePassword = helper.GetTfCfgString(cfg, "database.password")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.DatabasePassword = helper.GetTfCfgString(cfg, "database.password")
+	}
 	model.DatabaseDbname = helper.GetTfCfgString(cfg, "database.dbname")
 	model.SnapshotReadOnly = helper.GetTfCfgString(cfg, "snapshot.read.only.user.defined")
 	model.DatabaseSSLMode = helper.GetTfCfgString(cfg, "database.sslmode")
@@ -2,9 +2,11 @@ package source
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"encoding/json"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -25,9 +27,10 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ res.Resource                = &SourceSQLServerResource{}
-	_ res.ResourceWithConfigure   = &SourceSQLServerResource{}
-	_ res.ResourceWithImportState = &SourceSQLServerResource{}
+	_ res.Resource                     = &SourceSQLServerResource{}
+	_ res.ResourceWithConfigure        = &SourceSQLServerResource{}
+	_ res.ResourceWithConfigValidators = &SourceSQLServerResource{}
+	_ res.ResourceWithImportState      = &SourceSQLServerResource{}
 )
 
 func NewSourceSQLServerResource() res.Resource {
@@ -49,6 +52,8 @@ type SourceSQLServerResourceModel struct {
 	DatabasePort                            types.Int64  `tfsdk:"database_port"`
 	DatabaseUser                            types.String `tfsdk:"database_user"`
 	DatabasePassword                        types.String `tfsdk:"database_password"`
+	DatabasePasswordWO                      types.String `tfsdk:"database_password_wo"`
+	DatabasePasswordWOVersion               types.Int64  `tfsdk:"database_password_wo_version"`
 	DatabaseName                            types.String `tfsdk:"database_dbname"`
 	SchemaIncludeList                       types.String `tfsdk:"schema_include_list"`
 	TableIncludeList                        types.String `tfsdk:"table_include_list"`
@@ -68,6 +73,8 @@ type SourceSQLServerResourceModel struct {
 	SnapshotParallelism                     types.Int64 `tfsdk:"snapshot_parallelism"`
 	SnapshotLargeTableThreshold             types.Int64 `tfsdk:"snapshot_large_table_threshold"`
 	SnapshotCustomTableConfig               map[string]snapshotCustomTableConfigModel `tfsdk:"snapshot_custom_table_config"`
+	WaitForRunning                          types.Bool   `tfsdk:"wait_for_running"`
+	Timeouts                                timeouts.Value `tfsdk:"timeouts"`
 }
 
 type snapshotCustomTableConfigModel struct {
@@ -120,15 +127,20 @@ func (r *SourceSQLServerResource) Schema(ctx context.Context, req res.SchemaRequ
 				MarkdownDescription: "Username to access the database",
 			},
 			"database_password": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				Description:         "Password to access the database",
-				MarkdownDescription: "Password to access the database",
+				Description:         "Password to access the database. Exactly one of database_password or database_password_wo must be set.",
+				MarkdownDescription: "Password to access the database. Exactly one of `database_password` or `database_password_wo` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("database_password_wo")),
+				},
 			},
+			"database_password_wo":         helper.WriteOnlyAttribute("database_password"),
+			"database_password_wo_version": helper.WriteOnlyVersionAttribute("database_password"),
 			"database_dbname": schema.StringAttribute{
 				Required:            true,
-				Description:         "Source Databases",
-				MarkdownDescription: "Source Databases",
+				Description:         "Source Databases. Changing it forces a new resource to be created.",
+				MarkdownDescription: "Source Databases. Changing it forces a new resource to be created.",
 			},
 			"schema_include_list": schema.StringAttribute{
 				Required:            true,
@@ -260,8 +272,23 @@ func (r *SourceSQLServerResource) Schema(ctx context.Context, req res.SchemaRequ
 				Description:         "Explicitly set nb of parallel chunks for tables. Format: {\"db.Some_Tbl\": {\"chunks\": 5}}. This allows manual settings for parallelization when stats are outdated and estimated table size cannot be computed reliably",
 				MarkdownDescription: "Explicitly set nb of parallel chunks for tables. Format: {\"db.Some_Tbl\": {\"chunks\": 5}}. This allows manual settings for parallelization when stats are outdated and estimated table size cannot be computed reliably",
 			},
+			"wait_for_running": helper.WaitForRunningAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
 		},
 	}
+
+	helper.RequiresReplace(&resp.Schema, helper.ImmutableSourceAttributes[r.connector_code])
+}
+
+// ConfigValidators reports conflicting options at plan time rather than
+// during the apply.
+func (r *SourceSQLServerResource) ConfigValidators(ctx context.Context) []res.ConfigValidator {
+	return []res.ConfigValidator{
+		helper.RequiredWhenTrue("ssh_enabled", "ssh_host"),
+		helper.OnlyWhenTrue("heartbeat_enabled", "heartbeat_data_collection_schema_or_database"),
+	}
 }
 
 func (r *SourceSQLServerResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
@@ -287,12 +314,24 @@ func (r *SourceSQLServerResource) Create(ctx context.Context, req res.CreateRequ
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("database_password_wo"), &plan.DatabasePasswordWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
 	plan.Connector = types.StringValue(r.connector_code)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	config, err := r.model2ConfigMap(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -322,6 +361,17 @@ func (r *SourceSQLServerResource) Create(ctx context.Context, req res.CreateRequ
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	// A source that does not start is saved anyway, tainted
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating SQLServer source",
+				fmt.Sprintf("Unable to create SQLServer source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -334,6 +384,8 @@ func (r *SourceSQLServerResource) Read(ctx context.Context, req res.ReadRequest,
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -341,6 +393,10 @@ func (r *SourceSQLServerResource) Read(ctx context.Context, req res.ReadRequest,
 
 	sourceID := state.ID.ValueString()
 	source, err := r.client.GetSource(ctx, sourceID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading SQLServer source",
@@ -348,14 +404,15 @@ func (r *SourceSQLServerResource) Read(ctx context.Context, req res.ReadRequest,
 		)
 		return
 	}
-	if source == nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
 
 	state.Name = types.StringValue(source.Name)
 	state.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &state)
+	// wait_for_running is not known to the API, e.g. after an import
+	if state.WaitForRunning.IsNull() {
+		state.WaitForRunning = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -368,9 +425,21 @@ func (r *SourceSQLServerResource) Update(ctx context.Context, req res.UpdateRequ
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// Write-only secrets are only available in the config
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("database_password_wo"), &plan.DatabasePasswordWO)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, plan)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", plan))
 	config, err := r.model2ConfigMap(plan)
@@ -401,6 +470,16 @@ func (r *SourceSQLServerResource) Update(ctx context.Context, req res.UpdateRequ
 	plan.Connector = types.StringValue(source.Connector)
 	r.configMap2Model(source.Config, &plan)
 
+	if plan.WaitForRunning.ValueBool() {
+		_, err = helper.WaitForRunning(ctx, r.client.GetSourceStatus, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating SQLServer source",
+				fmt.Sprintf("Unable to update SQLServer source, got error: %s", err),
+			)
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -413,10 +492,20 @@ func (r *SourceSQLServerResource) Delete(ctx context.Context, req res.DeleteRequ
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	ctx = helper.MaskSensitiveValues(ctx, r, state)
+	ctx = helper.WithoutSecretsWhenWriteOnly(ctx, state)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.DeleteSource(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -428,7 +517,7 @@ func (r *SourceSQLServerResource) Delete(ctx context.Context, req res.DeleteRequ
 }
 
 func (r *SourceSQLServerResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
-	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	helper.ImportSourceState(ctx, r.client, r.connector_code, req, resp)
 }
 
 // Helpers
@@ -469,7 +558,7 @@ func (r *SourceSQLServerResource) model2ConfigMap(model SourceSQLServerResourceM
 		"database.hostname.user.defined":               model.DatabaseHostname.ValueString(),
 		"database.port.user.defined":                   int(model.DatabasePort.ValueInt64()),
 		"database.user":                                model.DatabaseUser.ValueString(),
-		"database.password":                            model.DatabasePassword.ValueString(),
+		"database.password":                            helper.PreferWriteOnly(model.DatabasePasswordWO, model.DatabasePassword).ValueString(),
 		"database.names":                               model.DatabaseName.ValueString(),
 		"schema.include.list":                          model.SchemaIncludeList.ValueString(),
 		"table.include.list.user.defined":              model.TableIncludeList.ValueString(),
@@ -499,7 +588,10 @@ func (r *SourceSQLServerResource) configMap2Model(cfg map[string]any, model *Sou
 	model.DatabaseHostname = helper.GetTfCfgString(cfg, "database.hostname.user.defined")
 	model.DatabasePort = helper.GetTfCfgInt64(cfg, "database.port.user.defined")
 	model.DatabaseUser = helper.GetTfCfgString(cfg, "database.user")
-	model.DatabasePassword = helper.GetTfCfgString(cfg, "database.password")
+	// The API does not return the secrets of a resource using write-only ones
+	if !helper.UsesWriteOnly(*model) {
+		model.DatabasePassword = helper.GetTfCfgString(cfg, "database.password")
+	}
 	model.DatabaseName = helper.GetTfCfgString(cfg, "database.names")
 	model.SchemaIncludeList = helper.GetTfCfgString(cfg, "schema.include.list")
 	model.TableIncludeList = helper.GetTfCfgString(cfg, "table.include.list.user.defined")
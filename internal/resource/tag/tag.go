@@ -0,0 +1,370 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	res "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/helper"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ res.Resource                = &TagResource{}
+	_ res.ResourceWithConfigure   = &TagResource{}
+	_ res.ResourceWithImportState = &TagResource{}
+	_ res.ResourceWithModifyPlan  = &TagResource{}
+)
+
+func NewTagResource() res.Resource {
+	return &TagResource{}
+}
+
+// TagResource defines the resource implementation.
+type TagResource struct {
+	client api.StreamkapAPI
+}
+
+// TagResourceModel describes the resource data model.
+type TagResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Type        types.List     `tfsdk:"type"`
+	System      types.Bool     `tfsdk:"system"`
+	Custom      types.Bool     `tfsdk:"custom"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *TagResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag"
+}
+
+func (r *TagResource) Schema(ctx context.Context, req res.SchemaRequest, resp *res.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Tag resource. System tags such as `Development` and `Production` can be imported but not modified or deleted.",
+		MarkdownDescription: "Tag resource. System tags such as `Development` and `Production` can be imported but not modified or deleted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Tag identifier",
+				MarkdownDescription: "Tag identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "Tag name",
+				MarkdownDescription: "Tag name",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Description:         "Tag description",
+				MarkdownDescription: "Tag description",
+			},
+			"type": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				Description:         "List of the object types the tag applies to, e.g. pipelines",
+				MarkdownDescription: "List of the object types the tag applies to, e.g. `pipelines`",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"system": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Is the tag a system tag",
+				MarkdownDescription: "Is the tag a system tag",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"custom": schema.BoolAttribute{
+				Computed:            true,
+				Description:         "Is the tag a custom tag",
+				MarkdownDescription: "Is the tag a custom tag",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *TagResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Tag Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = client
+}
+
+// ModifyPlan rejects changes to system tags at plan time rather than
+// halfway through an apply.
+func (r *TagResource) ModifyPlan(ctx context.Context, req res.ModifyPlanRequest, resp *res.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state TagResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !state.System.ValueBool() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.AddError(
+			"Cannot delete system tag",
+			fmt.Sprintf("Tag %s (%s) is a system tag and cannot be deleted. "+
+				"Use `terraform state rm` to stop managing it instead.", state.ID.ValueString(), state.Name.ValueString()),
+		)
+		return
+	}
+
+	var plan TagResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Values only known at apply time are checked by Update instead.
+	if !plan.Name.Equal(state.Name) && !plan.Name.IsUnknown() ||
+		!plan.Description.Equal(state.Description) && !plan.Description.IsUnknown() ||
+		!plan.Type.Equal(state.Type) && !plan.Type.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Cannot modify system tag",
+			fmt.Sprintf("Tag %s (%s) is a system tag and cannot be modified.", state.ID.ValueString(), state.Name.ValueString()),
+		)
+	}
+}
+
+func (r *TagResource) Create(ctx context.Context, req res.CreateRequest, resp *res.CreateResponse) {
+	var plan TagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	payload, diags := r.model2API(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tag, err := r.client.CreateTag(ctx, payload)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating tag",
+			fmt.Sprintf("Unable to create tag, got error: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.api2Model(ctx, *tag, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TagResource) Read(ctx context.Context, req res.ReadRequest, resp *res.ReadResponse) {
+	var state TagResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagID := state.ID.ValueString()
+	tag, err := r.client.GetTag(ctx, tagID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading tag",
+			fmt.Sprintf("Unable to read tag, got error: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.api2Model(ctx, *tag, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TagResource) Update(ctx context.Context, req res.UpdateRequest, resp *res.UpdateResponse) {
+	var plan, state TagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if state.System.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Error updating tag",
+			fmt.Sprintf("Tag %s is a system tag and cannot be modified", state.ID.ValueString()),
+		)
+		return
+	}
+
+	payload, diags := r.model2API(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tag, err := r.client.UpdateTag(ctx, state.ID.ValueString(), payload)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating tag",
+			fmt.Sprintf("Unable to update tag, got error: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.api2Model(ctx, *tag, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TagResource) Delete(ctx context.Context, req res.DeleteRequest, resp *res.DeleteResponse) {
+	var state TagResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if state.System.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Error deleting tag",
+			fmt.Sprintf("Tag %s is a system tag and cannot be deleted", state.ID.ValueString()),
+		)
+		return
+	}
+
+	err := r.client.DeleteTag(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting tag",
+			fmt.Sprintf("Unable to delete tag, got error: %s", err),
+		)
+		return
+	}
+}
+
+func (r *TagResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
+	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Helpers
+func (r *TagResource) model2API(ctx context.Context, model TagResourceModel) (api.TagPayload, diag.Diagnostics) {
+	tagTypes := []string{}
+	diags := model.Type.ElementsAs(ctx, &tagTypes, false)
+
+	return api.TagPayload{
+		Name:        model.Name.ValueString(),
+		Description: model.Description.ValueString(),
+		Type:        tagTypes,
+	}, diags
+}
+
+func (r *TagResource) api2Model(ctx context.Context, apiObject api.Tag, model *TagResourceModel) diag.Diagnostics {
+	// Copy the API Object to the model
+	model.ID = types.StringValue(apiObject.ID)
+	model.Name = types.StringValue(apiObject.Name)
+	model.Description = types.StringValue(apiObject.Description)
+
+	tagTypes := []string{}
+	tagTypes = append(tagTypes, apiObject.Type...)
+	typeList, diags := types.ListValueFrom(ctx, types.StringType, tagTypes)
+	model.Type = typeList
+
+	model.System = types.BoolValue(apiObject.System)
+	model.Custom = types.BoolPointerValue(apiObject.Custom)
+
+	return diags
+}
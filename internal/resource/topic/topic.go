@@ -2,15 +2,26 @@ package topic
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	res "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/helper"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -18,6 +29,7 @@ var (
 	_ res.Resource                = &TopicResource{}
 	_ res.ResourceWithConfigure   = &TopicResource{}
 	_ res.ResourceWithImportState = &TopicResource{}
+	_ res.ResourceWithModifyPlan  = &TopicResource{}
 )
 
 func NewTopicResource() res.Resource {
@@ -26,13 +38,21 @@ func NewTopicResource() res.Resource {
 
 // TopicResource defines the resource implementation.
 type TopicResource struct {
-	client         api.StreamkapAPI
+	client api.StreamkapAPI
 }
 
 // TopicResourceModel describes the resource data model.
 type TopicResourceModel struct {
-	TopicID            types.String  `tfsdk:"topic_id"`
-	PartitionCount     types.Int64   `tfsdk:"partition_count"`
+	TopicID            types.String   `tfsdk:"topic_id"`
+	PartitionCount     types.Int64    `tfsdk:"partition_count"`
+	ReplicationFactor  types.Int64    `tfsdk:"replication_factor"`
+	RetentionMs        types.Int64    `tfsdk:"retention_ms"`
+	CleanupPolicy      types.String   `tfsdk:"cleanup_policy"`
+	MinCompactionLagMs types.Int64    `tfsdk:"min_compaction_lag_ms"`
+	MaxCompactionLagMs types.Int64    `tfsdk:"max_compaction_lag_ms"`
+	MinInsyncReplicas  types.Int64    `tfsdk:"min_insync_replicas"`
+	Managed            types.Bool     `tfsdk:"managed"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *TopicResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
@@ -41,20 +61,114 @@ func (r *TopicResource) Metadata(ctx context.Context, req res.MetadataRequest, r
 
 func (r *TopicResource) Schema(ctx context.Context, req res.SchemaRequest, resp *res.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description:         "Topic resource",
-		MarkdownDescription: "Topic resource",
+		Description: "Topic resource. The topic is created unless a source already produces it, " +
+			"in which case it is configured in place. Destroying the resource deletes the topic only if it created it.",
+		MarkdownDescription: "Topic resource. The topic is created unless a source already produces it, " +
+			"in which case it is configured in place. Destroying the resource deletes the topic only if it created it.",
 		Attributes: map[string]schema.Attribute{
 			"topic_id": schema.StringAttribute{
 				Description:         "Topic ID",
 				MarkdownDescription: "Topic ID",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"partition_count": schema.Int64Attribute{
 				Required:            true,
-				Description:         "Partition Count",
-				MarkdownDescription: "Partition Count",
+				Description:         "Partition Count. It can be increased but never decreased.",
+				MarkdownDescription: "Partition Count. It can be increased but never decreased.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"replication_factor": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Number of replicas of each partition. Changing it forces a new topic.",
+				MarkdownDescription: "Number of replicas of each partition. Changing it forces a new topic.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"retention_ms": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "How long messages are kept, in milliseconds (retention.ms). -1 keeps them forever.",
+				MarkdownDescription: "How long messages are kept, in milliseconds (`retention.ms`). `-1` keeps them forever.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(-1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"cleanup_policy": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Cleanup policy (cleanup.policy): delete, compact or compact,delete",
+				MarkdownDescription: "Cleanup policy (`cleanup.policy`): `delete`, `compact` or `compact,delete`",
+				Validators: []validator.String{
+					stringvalidator.OneOf("delete", "compact", "compact,delete"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"min_compaction_lag_ms": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Minimum time a message stays uncompacted, in milliseconds (min.compaction.lag.ms)",
+				MarkdownDescription: "Minimum time a message stays uncompacted, in milliseconds (`min.compaction.lag.ms`)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_compaction_lag_ms": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Maximum time a message stays uncompacted, in milliseconds (max.compaction.lag.ms)",
+				MarkdownDescription: "Maximum time a message stays uncompacted, in milliseconds (`max.compaction.lag.ms`)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"min_insync_replicas": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Minimum number of in-sync replicas for a write to succeed (min.insync.replicas)",
+				MarkdownDescription: "Minimum number of in-sync replicas for a write to succeed (`min.insync.replicas`)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"managed": schema.BoolAttribute{
+				Computed: true,
+				Description: "Whether the resource created the topic. Only such topics are deleted on destroy; " +
+					"topics produced by a source, or imported, are only removed from the state.",
+				MarkdownDescription: "Whether the resource created the topic. Only such topics are deleted on destroy; " +
+					"topics produced by a source, or imported, are only removed from the state.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -76,6 +190,34 @@ func (r *TopicResource) Configure(ctx context.Context, req res.ConfigureRequest,
 	r.client = client
 }
 
+// ModifyPlan rejects a partition count decrease at plan time, Kafka cannot
+// remove partitions from a topic.
+func (r *TopicResource) ModifyPlan(ctx context.Context, req res.ModifyPlanRequest, resp *res.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state TopicResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.PartitionCount.IsUnknown() || !plan.TopicID.Equal(state.TopicID) {
+		return
+	}
+	if plan.PartitionCount.ValueInt64() < state.PartitionCount.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("partition_count"),
+			"Cannot decrease partition count",
+			fmt.Sprintf("Topic %s has %d partitions and cannot be shrunk to %d. "+
+				"Partitions can only be added; to use fewer partitions, create a new topic.",
+				state.TopicID.ValueString(), state.PartitionCount.ValueInt64(), plan.PartitionCount.ValueInt64()),
+		)
+	}
+}
+
 func (r *TopicResource) Create(ctx context.Context, req res.CreateRequest, resp *res.CreateResponse) {
 	var plan TopicResourceModel
 
@@ -86,19 +228,61 @@ func (r *TopicResource) Create(ctx context.Context, req res.CreateRequest, resp
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "Pre CREATE ===> plan: "+fmt.Sprintf("%+v", plan))
 
-	topic, err := r.client.UpdateTopic(ctx, plan.TopicID.ValueString(), api.Topic{
-		TopicID: plan.TopicID.ValueString(),
-		PartitionCount: int(plan.PartitionCount.ValueInt64()),
-	})
-	if err != nil {
+	// Topics produced by a source already exist, they are only configured.
+	topicID := plan.TopicID.ValueString()
+	existing, err := r.client.GetTopic(ctx, topicID)
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
 		resp.Diagnostics.AddError(
-			"Error updateing topic",
-			fmt.Sprintf("Unable to update topic, got error: %s", err),
+			"Error creating topic",
+			fmt.Sprintf("Unable to read topic, got error: %s", err),
 		)
 		return
 	}
+
+	var topic *api.Topic
+	if existing == nil {
+		topic, err = r.client.CreateTopic(ctx, r.model2API(plan))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating topic",
+				fmt.Sprintf("Unable to create topic, got error: %s", err),
+			)
+			return
+		}
+		plan.Managed = types.BoolValue(true)
+	} else {
+		if !plan.ReplicationFactor.IsUnknown() && !plan.ReplicationFactor.IsNull() &&
+			plan.ReplicationFactor.ValueInt64() != int64(existing.ReplicationFactor) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("replication_factor"),
+				"Error creating topic",
+				fmt.Sprintf("Topic %s already exists with a replication factor of %d, which cannot be changed",
+					topicID, existing.ReplicationFactor),
+			)
+			return
+		}
+		payload := r.model2API(plan)
+		payload.ReplicationFactor = existing.ReplicationFactor
+		topic, err = r.client.UpdateTopic(ctx, topicID, payload)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating topic",
+				fmt.Sprintf("Unable to update existing topic, got error: %s", err),
+			)
+			return
+		}
+		plan.Managed = types.BoolValue(false)
+	}
 	tflog.Debug(ctx, "Post CREATE ===> config: "+fmt.Sprintf("%+v", topic))
 
 	r.configMap2Model(*topic, &plan)
@@ -123,21 +307,23 @@ func (r *TopicResource) Read(ctx context.Context, req res.ReadRequest, resp *res
 
 	topicID := state.TopicID.ValueString()
 	topic, err := r.client.GetTopic(ctx, topicID)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading topic",
-			fmt.Sprintf("Unable to read topic, got error: %s", err),
-		)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
 		return
 	}
-	if topic == nil {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading topic",
-			fmt.Sprintf("topic %s does not exist", topicID),
+			fmt.Sprintf("Unable to read topic, got error: %s", err),
 		)
 		return
 	}
 	r.configMap2Model(*topic, &state)
+	// Imported topics, and those of states older than the attribute, are
+	// never deleted.
+	if state.Managed.IsNull() {
+		state.Managed = types.BoolValue(false)
+	}
 	tflog.Info(ctx, "===> config: "+fmt.Sprintf("%+v", state))
 
 	// Save updated data into Terraform state
@@ -156,13 +342,18 @@ func (r *TopicResource) Update(ctx context.Context, req res.UpdateRequest, resp
 		return
 	}
 
-	topic, err := r.client.UpdateTopic(ctx, plan.TopicID.ValueString(), api.Topic{
-		TopicID: plan.TopicID.ValueString(),
-		PartitionCount: int(plan.PartitionCount.ValueInt64()),
-	})
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	topic, err := r.client.UpdateTopic(ctx, plan.TopicID.ValueString(), r.model2API(plan))
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error updateing topic",
+			"Error updating topic",
 			fmt.Sprintf("Unable to update topic, got error: %s", err),
 		)
 		return
@@ -186,16 +377,86 @@ func (r *TopicResource) Delete(ctx context.Context, req res.DeleteRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Topics the resource did not create, e.g. produced by a source, are
+	// only removed from the state.
+	if !state.Managed.ValueBool() {
+		tflog.Info(ctx, fmt.Sprintf("Topic %s was not created by Terraform, leaving it in place", state.TopicID.ValueString()))
+		return
+	}
+
+	err := r.client.DeleteTopic(ctx, state.TopicID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting topic",
+			fmt.Sprintf("Unable to delete topic, got error: %s", err),
+		)
+		return
+	}
 }
 
 func (r *TopicResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
 	res.ImportStatePassthroughID(ctx, path.Root("topic_id"), req, resp)
 }
 
-func (r *TopicResource) configMap2Model(cfg api.Topic, model *TopicResourceModel) (err error) {
+// Helpers
+
+// int64Configs maps the integer topic config keys to their model attribute.
+func (r *TopicResource) int64Configs(model *TopicResourceModel) map[string]*types.Int64 {
+	return map[string]*types.Int64{
+		api.TopicConfigRetentionMs:        &model.RetentionMs,
+		api.TopicConfigMinCompactionLagMs: &model.MinCompactionLagMs,
+		api.TopicConfigMaxCompactionLagMs: &model.MaxCompactionLagMs,
+		api.TopicConfigMinInsyncReplicas:  &model.MinInsyncReplicas,
+	}
+}
+
+func (r *TopicResource) model2API(model TopicResourceModel) api.Topic {
+	config := map[string]string{}
+	for key, value := range r.int64Configs(&model) {
+		if !value.IsNull() && !value.IsUnknown() {
+			config[key] = strconv.FormatInt(value.ValueInt64(), 10)
+		}
+	}
+	if !model.CleanupPolicy.IsNull() && !model.CleanupPolicy.IsUnknown() {
+		config[api.TopicConfigCleanupPolicy] = model.CleanupPolicy.ValueString()
+	}
+
+	return api.Topic{
+		TopicID:           model.TopicID.ValueString(),
+		PartitionCount:    int(model.PartitionCount.ValueInt64()),
+		ReplicationFactor: int(model.ReplicationFactor.ValueInt64()),
+		Config:            config,
+	}
+}
+
+func (r *TopicResource) configMap2Model(cfg api.Topic, model *TopicResourceModel) {
 	// Copy the config map to the model
 	model.TopicID = types.StringValue(cfg.TopicID)
 	model.PartitionCount = types.Int64Value(int64(cfg.PartitionCount))
 
-	return
+	model.ReplicationFactor = types.Int64Null()
+	if cfg.ReplicationFactor > 0 {
+		model.ReplicationFactor = types.Int64Value(int64(cfg.ReplicationFactor))
+	}
+
+	for key, value := range r.int64Configs(model) {
+		*value = types.Int64Null()
+		if v, err := strconv.ParseInt(cfg.Config[key], 10, 64); err == nil {
+			*value = types.Int64Value(v)
+		}
+	}
+
+	model.CleanupPolicy = types.StringNull()
+	if v, ok := cfg.Config[api.TopicConfigCleanupPolicy]; ok {
+		model.CleanupPolicy = types.StringValue(v)
+	}
 }
@@ -0,0 +1,462 @@
+package transform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	res "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/api"
+	"github.com/streamkap-com/terraform-provider-streamkap/internal/helper"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ res.Resource                = &TransformResource{}
+	_ res.ResourceWithConfigure   = &TransformResource{}
+	_ res.ResourceWithImportState = &TransformResource{}
+	_ res.ResourceWithModifyPlan  = &TransformResource{}
+)
+
+// appliedCodeKey is the private state key of the SHA-256 of the code the API
+// returned on the last apply, which may differ from the code sent when the
+// API normalizes it.
+const appliedCodeKey = "applied_code_sha256"
+
+// privateState is implemented by the private state of the requests and
+// responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func NewTransformResource() res.Resource {
+	return &TransformResource{}
+}
+
+// TransformResource defines the resource implementation.
+type TransformResource struct {
+	client api.StreamkapAPI
+}
+
+// TransformResourceModel describes the resource data model.
+type TransformResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	TransformType      types.String   `tfsdk:"transform_type"`
+	Language           types.String   `tfsdk:"language"`
+	Code               types.String   `tfsdk:"code"`
+	CodeFile           types.String   `tfsdk:"code_file"`
+	CodeSHA256         types.String   `tfsdk:"code_sha256"`
+	InputTopicPattern  types.String   `tfsdk:"input_topic_pattern"`
+	OutputTopicPattern types.String   `tfsdk:"output_topic_pattern"`
+	StartTime          types.String   `tfsdk:"start_time"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *TransformResource) Metadata(ctx context.Context, req res.MetadataRequest, resp *res.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_transform"
+}
+
+func (r *TransformResource) Schema(ctx context.Context, req res.SchemaRequest, resp *res.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Transform resource",
+		MarkdownDescription: "Transform resource",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Transform identifier",
+				MarkdownDescription: "Transform identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				Description:         "Transform name",
+				MarkdownDescription: "Transform name",
+			},
+			"transform_type": schema.StringAttribute{
+				Required:            true,
+				Description:         "Transform type. Changing it forces a new transform.",
+				MarkdownDescription: "Transform type. Changing it forces a new transform.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("map_filter", "enrich", "enrich_async", "fan_out", "sql_join", "rollup", "un_nesting", "toast_handling"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"language": schema.StringAttribute{
+				Required:            true,
+				Description:         "Language of the transform code",
+				MarkdownDescription: "Language of the transform code",
+				Validators: []validator.String{
+					stringvalidator.OneOf("JavaScript", "Python", "SQL"),
+				},
+			},
+			"code": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Transform code. Exactly one of code or code_file must be set.",
+				MarkdownDescription: "Transform code. Exactly one of `code` or `code_file` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("code_file")),
+				},
+			},
+			"code_file": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Path to a file holding the transform code. The file is read at plan time, so editing it updates the transform.",
+				MarkdownDescription: "Path to a file holding the transform code. The file is read at plan time, so editing it updates the transform.",
+			},
+			"code_sha256": schema.StringAttribute{
+				Computed:            true,
+				Description:         "SHA-256 of the transform code, from code or the content of code_file. A change of the code shows up as a change of this attribute.",
+				MarkdownDescription: "SHA-256 of the transform code, from `code` or the content of `code_file`. A change of the code shows up as a change of this attribute.",
+			},
+			"input_topic_pattern": schema.StringAttribute{
+				Required:            true,
+				Description:         "Regular expression matching the topics consumed by the transform",
+				MarkdownDescription: "Regular expression matching the topics consumed by the transform",
+			},
+			"output_topic_pattern": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Pattern of the topics produced by the transform. Defaults to the API's naming.",
+				MarkdownDescription: "Pattern of the topics produced by the transform. Defaults to the API's naming.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         "Start time. Defaults to the API's start time.",
+				MarkdownDescription: "Start time. Defaults to the API's start time.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": helper.TimeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *TransformResource) Configure(ctx context.Context, req res.ConfigureRequest, resp *res.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(api.StreamkapAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Transform Configure Type",
+			fmt.Sprintf("Expected api.StreamkapAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = client
+}
+
+// ModifyPlan hashes the code so that editing it, inline or in code_file,
+// plans an update.
+func (r *TransformResource) ModifyPlan(ctx context.Context, req res.ModifyPlanRequest, resp *res.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan TransformResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Code.IsUnknown() || plan.CodeFile.IsUnknown() {
+		plan.CodeSHA256 = types.StringUnknown()
+	} else {
+		code, err := r.code(plan)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("code_file"),
+				"Error reading transform code",
+				fmt.Sprintf("Unable to read transform code, got error: %s", err),
+			)
+			return
+		}
+		plan.CodeSHA256 = types.StringValue(codeSHA256(code))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("code_sha256"), plan.CodeSHA256)...)
+}
+
+func (r *TransformResource) Create(ctx context.Context, req res.CreateRequest, resp *res.CreateResponse) {
+	var plan TransformResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	payload, err := r.model2API(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating transform",
+			fmt.Sprintf("Unable to create transform, got error: %s", err),
+		)
+		return
+	}
+	transform, err := r.client.CreateTransform(ctx, *payload)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating transform",
+			fmt.Sprintf("Unable to create transform, got error: %s", err),
+		)
+		return
+	}
+
+	r.applied2Model(ctx, *transform, payload.Code, &plan, resp.Private, &resp.Diagnostics)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TransformResource) Read(ctx context.Context, req res.ReadRequest, resp *res.ReadResponse) {
+	var state TransformResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	transformID := state.ID.ValueString()
+	transform, err := r.client.GetTransform(ctx, transformID)
+	if errors.Is(err, api.ErrNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading transform",
+			fmt.Sprintf("Unable to read transform, got error: %s", err),
+		)
+		return
+	}
+
+	r.api2Model(*transform, &state)
+	resp.Diagnostics.Append(r.apiCode2Model(ctx, transform.Code, &state, req.Private)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TransformResource) Update(ctx context.Context, req res.UpdateRequest, resp *res.UpdateResponse) {
+	var plan TransformResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	payload, err := r.model2API(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating transform",
+			fmt.Sprintf("Unable to update transform, got error: %s", err),
+		)
+		return
+	}
+	transform, err := r.client.UpdateTransform(ctx, plan.ID.ValueString(), *payload)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating transform",
+			fmt.Sprintf("Unable to update transform, got error: %s", err),
+		)
+		return
+	}
+
+	r.applied2Model(ctx, *transform, payload.Code, &plan, resp.Private, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TransformResource) Delete(ctx context.Context, req res.DeleteRequest, resp *res.DeleteResponse) {
+	var state TransformResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, helper.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteTransform(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting transform",
+			fmt.Sprintf("Unable to delete transform, got error: %s", err),
+		)
+		return
+	}
+}
+
+func (r *TransformResource) ImportState(ctx context.Context, req res.ImportStateRequest, resp *res.ImportStateResponse) {
+	res.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Helpers
+
+// code returns the inline code or the content of code_file.
+func (r *TransformResource) code(model TransformResourceModel) (string, error) {
+	if model.CodeFile.IsNull() {
+		return model.Code.ValueString(), nil
+	}
+	content, err := os.ReadFile(model.CodeFile.ValueString())
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func codeSHA256(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *TransformResource) model2API(model TransformResourceModel) (*api.Transform, error) {
+	code, err := r.code(model)
+	if err != nil {
+		return nil, err
+	}
+
+	// Let the API pick the start time when it is left to it.
+	var startTime *string
+	if !model.StartTime.IsUnknown() {
+		startTime = model.StartTime.ValueStringPointer()
+	}
+
+	return &api.Transform{
+		Name:               model.Name.ValueString(),
+		TransformType:      model.TransformType.ValueString(),
+		Language:           model.Language.ValueString(),
+		Code:               code,
+		InputTopicPattern:  model.InputTopicPattern.ValueString(),
+		OutputTopicPattern: model.OutputTopicPattern.ValueString(),
+		StartTime:          startTime,
+	}, nil
+}
+
+func (r *TransformResource) api2Model(apiObject api.Transform, model *TransformResourceModel) {
+	// Copy the API Object to the model
+	model.ID = types.StringValue(apiObject.ID)
+	model.Name = types.StringValue(apiObject.Name)
+	model.TransformType = types.StringValue(apiObject.TransformType)
+	model.Language = types.StringValue(apiObject.Language)
+	model.InputTopicPattern = types.StringValue(apiObject.InputTopicPattern)
+	model.OutputTopicPattern = types.StringValue(apiObject.OutputTopicPattern)
+	model.StartTime = types.StringPointerValue(apiObject.StartTime)
+}
+
+// applied2Model copies the transform returned by Create or Update to the
+// model. The planned code and code_sha256 are kept, as the API may return
+// the code normalized, and the hash of the returned code is saved to tell
+// it from later changes in Read.
+func (r *TransformResource) applied2Model(ctx context.Context, apiObject api.Transform, code string, model *TransformResourceModel, private privateState, diags *diag.Diagnostics) {
+	startTime := model.StartTime
+	r.api2Model(apiObject, model)
+	if !startTime.IsUnknown() {
+		model.StartTime = startTime
+	}
+
+	if model.CodeSHA256.IsUnknown() {
+		model.CodeSHA256 = types.StringValue(codeSHA256(code))
+	}
+
+	applied, err := json.Marshal(codeSHA256(apiObject.Code))
+	if err != nil {
+		diags.AddError("Error saving transform code hash", err.Error())
+		return
+	}
+	diags.Append(private.SetKey(ctx, appliedCodeKey, applied)...)
+}
+
+// apiCode2Model sets code_sha256, and code unless code_file is used, from the
+// code returned by the API when it changed since the last apply, so that
+// changes made outside of Terraform show up as drift.
+func (r *TransformResource) apiCode2Model(ctx context.Context, code string, model *TransformResourceModel, private privateState) diag.Diagnostics {
+	hash := codeSHA256(code)
+
+	applied, diags := private.GetKey(ctx, appliedCodeKey)
+	var appliedHash string
+	if len(applied) > 0 {
+		if err := json.Unmarshal(applied, &appliedHash); err != nil {
+			diags.AddError("Error reading transform code hash", err.Error())
+			return diags
+		}
+	}
+	if hash == appliedHash {
+		return diags
+	}
+
+	// The code of a code_file stays out of the state, only its hash is
+	// kept to detect changes made outside of Terraform.
+	if model.CodeFile.IsNull() {
+		model.Code = types.StringValue(code)
+	}
+	model.CodeSHA256 = types.StringValue(hash)
+	return diags
+}